@@ -38,13 +38,9 @@ func dataSourceDynatraceAlertingProfilesRead(ctx context.Context, d *schema.Reso
 	id, idOk := d.GetOk("id")
 	name, nameOk := d.GetOk("display_name")
 
-	alertingProfiles, _, err := dynatraceConfigClientV1.AlertingProfilesApi.GetAlertingProfiles(authConfigV1).Execute()
+	alertingProfiles, resp, err := dynatraceConfigClientV1.AlertingProfilesApi.GetAlertingProfiles(authConfigV1).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to get dynatrace alerting profiles",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to get dynatrace alerting profiles", err, resp, nil)...)
 		return diags
 	}
 
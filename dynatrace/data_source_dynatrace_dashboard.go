@@ -0,0 +1,344 @@
+package dynatrace
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDynatraceDashboard() *schema.Resource {
+	s := dashboardFilterSchema()
+
+	for k, v := range dashboardDataSchema() {
+		s[k] = v
+	}
+
+	s["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The ID of the dashboard.",
+	}
+	s["name"].Computed = true
+	s["owner"].Computed = true
+
+	return &schema.Resource{
+		ReadContext: dataSourceDynatraceDashboardRead,
+		Schema:      s,
+	}
+}
+
+// dashboardFilterSchema returns the arguments used to narrow down dashboards.
+func dashboardFilterSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of the dashboard.",
+		},
+		"owner": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The owner of the dashboard.",
+		},
+		"tags": &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Only match dashboards that have all of these tags.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+// dashboardDataSchema returns the computed attributes describing a dashboard, in the
+// structure of the dynatrace_dashboard resource and as JSON.
+func dashboardDataSchema() map[string]*schema.Schema {
+	dashboard := resourceDynatraceDashboard().Schema
+
+	tile := computedSchema(dashboard["tile"])
+	// the layout of a tile only exists in the configuration of the resource
+	delete(tile.Elem.(*schema.Resource).Schema, "layout")
+
+	return map[string]*schema.Schema{
+		"dashboard_metadata": computedSchema(dashboard["dashboard_metadata"]),
+		"tile":               tile,
+		"contents": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The dashboard as JSON without the fields assigned by the server, for the contents of a dynatrace_dashboard_json resource.",
+		},
+	}
+}
+
+// computedSchema returns a copy of a resource attribute with all of its nested
+// attributes turned into computed ones, for use in data sources.
+func computedSchema(s *schema.Schema) *schema.Schema {
+	c := &schema.Schema{
+		Type:        s.Type,
+		Description: s.Description,
+		Computed:    true,
+	}
+
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for k, v := range elem.Schema {
+			nested[k] = computedSchema(v)
+		}
+		c.Elem = &schema.Resource{Schema: nested}
+	case *schema.Schema:
+		c.Elem = &schema.Schema{Type: elem.Type}
+	}
+
+	return c
+}
+
+func dataSourceDynatraceDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	filter := expandDashboardStubFilter(d)
+
+	if id, ok := d.GetOk("id"); ok {
+		filter.id = id.(string)
+	}
+
+	dashboards, diags := findDashboards(m.(*ProviderConfiguration), filter)
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(dashboards) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dashboard not found",
+			Detail:   "The values given do not match with any dynatrace dashboard",
+		})
+		return diags
+	}
+
+	if len(dashboards) > 1 {
+		ids := make([]string, len(dashboards))
+		for i, dashboard := range dashboards {
+			ids[i] = dashboard.id
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Multiple dashboards found",
+			Detail:   fmt.Sprintf("The values given match %d dynatrace dashboards (%s), use the dynatrace_dashboards data source or narrow down the filters", len(ids), strings.Join(ids, ", ")),
+		})
+		return diags
+	}
+
+	dashboard := dashboards[0]
+
+	d.SetId(dashboard.id)
+
+	data, err := flattenDashboardData(&dashboard)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for k, v := range data {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func dataSourceDynatraceDashboards() *schema.Resource {
+	dashboardSchema := dashboardDataSchema()
+
+	dashboardSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ID of the dashboard.",
+	}
+	dashboardSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The name of the dashboard.",
+	}
+	dashboardSchema["owner"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The owner of the dashboard.",
+	}
+
+	s := dashboardFilterSchema()
+
+	s["ids"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The IDs of the matching dashboards.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	s["dashboards"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The configuration of the matching dashboards.",
+		Elem: &schema.Resource{
+			Schema: dashboardSchema,
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDynatraceDashboardsRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceDynatraceDashboardsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	dashboards, diags := findDashboards(m.(*ProviderConfiguration), expandDashboardStubFilter(d))
+	if diags.HasError() {
+		return diags
+	}
+
+	sort.Slice(dashboards, func(i, j int) bool {
+		return dashboards[i].id < dashboards[j].id
+	})
+
+	ids := make([]string, len(dashboards))
+	dds := make([]interface{}, len(dashboards))
+
+	for i, dashboard := range dashboards {
+		ids[i] = dashboard.id
+
+		v, err := flattenDashboardData(&dashboard)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		v["id"] = dashboard.id
+		dds[i] = v
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("dashboards", dds); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(ids, ",")))))
+
+	return diags
+}
+
+// dashboardStubFilter holds the criteria dashboards are narrowed down by.
+type dashboardStubFilter struct {
+	id    string
+	name  string
+	owner string
+	tags  []string
+}
+
+func expandDashboardStubFilter(d *schema.ResourceData) *dashboardStubFilter {
+	filter := &dashboardStubFilter{}
+
+	if name, ok := d.GetOk("name"); ok {
+		filter.name = name.(string)
+	}
+
+	if owner, ok := d.GetOk("owner"); ok {
+		filter.owner = owner.(string)
+	}
+
+	if tags, ok := d.GetOk("tags"); ok {
+		filter.tags = expandDashboardTags(tags.([]interface{}))
+	}
+
+	return filter
+}
+
+// foundDashboard is a dashboard returned by the API, both decoded and as received.
+type foundDashboard struct {
+	id        string
+	dashboard dashboardConfig
+	contents  []byte
+}
+
+// findDashboards lists the dashboards of the environment matching the owner and
+// tags of the filter, and returns the full configuration of those whose id and
+// name match as well.
+func findDashboards(providerConf *ProviderConfiguration, filter *dashboardStubFilter) ([]foundDashboard, diag.Diagnostics) {
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	var diags diag.Diagnostics
+
+	request := dynatraceConfigClientV1.DashboardsApi.GetDashboardStubsList(authConfigV1)
+	if len(filter.owner) != 0 {
+		request = request.Owner(filter.owner)
+	}
+	if len(filter.tags) != 0 {
+		request = request.Tags(filter.tags)
+	}
+
+	stubs, resp, err := request.Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to get dynatrace dashboards", err, resp, nil)...)
+		return nil, diags
+	}
+
+	dashboards := []foundDashboard{}
+
+	for _, stub := range stubs.Dashboards {
+		if len(filter.id) != 0 && stub.Id != filter.id {
+			continue
+		}
+
+		if len(filter.name) != 0 && stub.GetName() != filter.name {
+			continue
+		}
+
+		response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, "/dashboards/"+stub.Id, nil)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace dashboard", err, nil, nil)...)
+			return nil, diags
+		}
+
+		found := foundDashboard{id: stub.Id, contents: response}
+		if err := json.Unmarshal(response, &found.dashboard); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to read dynatrace dashboard",
+				Detail:   fmt.Sprintf("dashboard %s: %v", stub.Id, err),
+			})
+			return nil, diags
+		}
+
+		dashboards = append(dashboards, found)
+	}
+
+	return dashboards, diags
+}
+
+// flattenDashboardData maps a dashboard onto the attributes of dashboardDataSchema.
+func flattenDashboardData(dashboard *foundDashboard) (map[string]interface{}, error) {
+	contents, err := normalizeDashboardJSON(string(dashboard.contents))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"name":               dashboard.dashboard.DashboardMetadata.Name,
+		"owner":              dashboard.dashboard.DashboardMetadata.GetOwner(),
+		"dashboard_metadata": flattenDashboardMetadata(&dashboard.dashboard.DashboardMetadata),
+		"tile":               flattenDashboardTilesData(dashboard.dashboard.Tiles),
+		"contents":           contents,
+	}, nil
+}
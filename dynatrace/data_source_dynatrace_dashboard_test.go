@@ -0,0 +1,74 @@
+package dynatrace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDynatraceDataSourceDashboard_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s.dynatrace", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceDataSourceDashboardBasic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dynatrace_dashboard.test", "dashboard_metadata.0.name", name),
+				),
+			},
+			{
+				Config: testAccDynatraceDataSourceDashboardBasic(name) +
+					testAccDynatraceDataSourceDashboardRead(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.dynatrace_dashboard.test", "id", "dynatrace_dashboard.test", "id"),
+					resource.TestCheckResourceAttr("data.dynatrace_dashboard.test", "dashboard_metadata.0.tags.0", "golden"),
+					resource.TestCheckResourceAttr("data.dynatrace_dashboard.test", "tile.0.markdown", "## Golden"),
+					resource.TestCheckResourceAttrSet("data.dynatrace_dashboard.test", "contents"),
+					resource.TestCheckResourceAttr("data.dynatrace_dashboards.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.dynatrace_dashboards.test", "dashboards.0.name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccDynatraceDataSourceDashboardBasic(name string) string {
+	return fmt.Sprintf(`resource "dynatrace_dashboard" "test" {
+		dashboard_metadata {
+			name = "%s"
+			shared = false
+			tags = ["golden"]
+		}
+
+		tile {
+			name = "Markdown"
+			tile_type = "MARKDOWN"
+			configured = true
+			markdown = "## Golden"
+			bounds {
+				top = 0
+				left = 0
+				width = 304
+				height = 152
+			}
+		}
+	}
+`, name)
+}
+
+func testAccDynatraceDataSourceDashboardRead() string {
+	return fmt.Sprintf(`data "dynatrace_dashboard" "test" {
+    	name = "${dynatrace_dashboard.test.dashboard_metadata.0.name}"
+}
+
+data "dynatrace_dashboards" "test" {
+    	name = "${dynatrace_dashboard.test.dashboard_metadata.0.name}"
+    	tags = ["golden"]
+}
+`)
+}
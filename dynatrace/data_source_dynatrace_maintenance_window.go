@@ -0,0 +1,507 @@
+package dynatrace
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDynatraceMaintenanceWindow() *schema.Resource {
+	s := maintenanceWindowFilterSchema()
+
+	for k, v := range maintenanceWindowDataSchema() {
+		s[k] = v
+	}
+
+	s["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "The ID of the maintenance window.",
+	}
+	s["name"].Computed = true
+	s["type"].Computed = true
+
+	return &schema.Resource{
+		ReadContext: dataSourceDynatraceMaintenanceWindowRead,
+		Schema:      s,
+	}
+}
+
+// maintenanceWindowFilterSchema returns the arguments used to narrow down maintenance windows.
+func maintenanceWindowFilterSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of the maintenance window.",
+		},
+		"type": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The type of the maintenance: PLANNED or UNPLANNED.",
+			ValidateFunc: validation.StringInSlice([]string{"PLANNED", "UNPLANNED"}, true),
+		},
+		"active_at": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Only match maintenance windows whose schedule is active at this RFC 3339 timestamp, for example the result of timestamp().",
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+		"scope_entity": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only match maintenance windows whose scope explicitly includes this Dynatrace entity ID.",
+		},
+		"scope_mz_id": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only match maintenance windows with a scope matching rule restricted to this management zone ID.",
+		},
+	}
+}
+
+// maintenanceWindowDataSchema returns the computed attributes describing a maintenance window.
+func maintenanceWindowDataSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"description": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "A short description of the maintenance purpose.",
+		},
+		"suppression": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The type of suppression of alerting and problem detection during the maintenance.",
+		},
+		"scope": &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The scope of the maintenance window.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"entities": &schema.Schema{
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "A list of Dynatrace entities (for example, hosts or services) included in the scope.",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"match": &schema.Schema{
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "A matching rule for Dynatrace entities.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"type": &schema.Schema{
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: "The type of the Dynatrace entities picked up by matching.",
+								},
+								"mz_id": &schema.Schema{
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: "The ID of a management zone to which the matched entities must belong.",
+								},
+								"tag_combination": &schema.Schema{
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: "The logic that applies when several tags are specified: AND/OR.",
+								},
+								"tags": &schema.Schema{
+									Type:        schema.TypeList,
+									Computed:    true,
+									Description: "The tags used for matching.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"context": &schema.Schema{
+												Type:        schema.TypeString,
+												Computed:    true,
+												Description: "The origin of the tag, such as AWS or Cloud Foundry.",
+											},
+											"key": &schema.Schema{
+												Type:        schema.TypeString,
+												Computed:    true,
+												Description: "The key of the tag.",
+											},
+											"value": &schema.Schema{
+												Type:        schema.TypeString,
+												Computed:    true,
+												Description: "The value of the tag.",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"schedule": &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The schedule of the maintenance window.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"recurrence_type": &schema.Schema{
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The type of the schedule recurrence.",
+					},
+					"start": &schema.Schema{
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The start date and time of the maintenance window validity period in yyyy-mm-dd HH:mm format.",
+					},
+					"end": &schema.Schema{
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The end date and time of the maintenance window validity period in yyyy-mm-dd HH:mm format.",
+					},
+					"zone_id": &schema.Schema{
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The time zone of the start and end time.",
+					},
+					"recurrence": &schema.Schema{
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The recurrence of the maintenance window.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"day_of_week": &schema.Schema{
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: "The day of the week for weekly maintenance.",
+								},
+								"day_of_month": &schema.Schema{
+									Type:        schema.TypeInt,
+									Computed:    true,
+									Description: "The day of the month for monthly maintenance.",
+								},
+								"start_time": &schema.Schema{
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: "The start time of the maintenance window in HH:mm format.",
+								},
+								"duration_minutes": &schema.Schema{
+									Type:        schema.TypeInt,
+									Computed:    true,
+									Description: "The duration of the maintenance window in minutes.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDynatraceMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	filter, err := expandMaintenanceWindowFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if id, ok := d.GetOk("id"); ok {
+		filter.id = id.(string)
+	}
+
+	maintenanceWindows, diags := findMaintenanceWindows(m.(*ProviderConfiguration), filter)
+	if diags.HasError() {
+		return diags
+	}
+
+	if len(maintenanceWindows) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Maintenance window not found",
+			Detail:   "The values given do not match with any dynatrace maintenance window",
+		})
+		return diags
+	}
+
+	if len(maintenanceWindows) > 1 {
+		ids := make([]string, len(maintenanceWindows))
+		for i, mw := range maintenanceWindows {
+			ids[i] = mw.GetId()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Multiple maintenance windows found",
+			Detail:   fmt.Sprintf("The values given match %d dynatrace maintenance windows (%s), use the dynatrace_maintenance_windows data source or narrow down the filters", len(ids), strings.Join(ids, ", ")),
+		})
+		return diags
+	}
+
+	mw := maintenanceWindows[0]
+
+	d.SetId(mw.GetId())
+
+	for k, v := range flattenMaintenanceWindowData(&mw) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func dataSourceDynatraceMaintenanceWindows() *schema.Resource {
+	maintenanceWindowSchema := maintenanceWindowDataSchema()
+
+	maintenanceWindowSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ID of the maintenance window. Use it to import the maintenance window into a dynatrace_maintenance_window resource.",
+	}
+	maintenanceWindowSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The name of the maintenance window.",
+	}
+	maintenanceWindowSchema["type"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The type of the maintenance: PLANNED or UNPLANNED.",
+	}
+
+	s := maintenanceWindowFilterSchema()
+
+	s["ids"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The IDs of the matching maintenance windows.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	s["maintenance_windows"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The configuration of the matching maintenance windows.",
+		Elem: &schema.Resource{
+			Schema: maintenanceWindowSchema,
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDynatraceMaintenanceWindowsRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceDynatraceMaintenanceWindowsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	filter, err := expandMaintenanceWindowFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	maintenanceWindows, diags := findMaintenanceWindows(m.(*ProviderConfiguration), filter)
+	if diags.HasError() {
+		return diags
+	}
+
+	sort.Slice(maintenanceWindows, func(i, j int) bool {
+		return maintenanceWindows[i].GetId() < maintenanceWindows[j].GetId()
+	})
+
+	ids := make([]string, len(maintenanceWindows))
+	mws := make([]interface{}, len(maintenanceWindows))
+
+	for i, mw := range maintenanceWindows {
+		ids[i] = mw.GetId()
+
+		v := flattenMaintenanceWindowData(&mw)
+		v["id"] = mw.GetId()
+		mws[i] = v
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("maintenance_windows", mws); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(ids, ",")))))
+
+	return diags
+}
+
+// maintenanceWindowFilter holds the criteria maintenance windows are narrowed down by.
+type maintenanceWindowFilter struct {
+	id          string
+	name        string
+	mwType      string
+	scopeEntity string
+	scopeMzID   string
+	activeAt    *time.Time
+}
+
+func expandMaintenanceWindowFilter(d *schema.ResourceData) (*maintenanceWindowFilter, error) {
+	filter := &maintenanceWindowFilter{}
+
+	if name, ok := d.GetOk("name"); ok {
+		filter.name = name.(string)
+	}
+
+	if mwType, ok := d.GetOk("type"); ok {
+		filter.mwType = mwType.(string)
+	}
+
+	if scopeEntity, ok := d.GetOk("scope_entity"); ok {
+		filter.scopeEntity = scopeEntity.(string)
+	}
+
+	if scopeMzID, ok := d.GetOk("scope_mz_id"); ok {
+		filter.scopeMzID = scopeMzID.(string)
+	}
+
+	if activeAt, ok := d.GetOk("active_at"); ok {
+		at, err := time.Parse(time.RFC3339, activeAt.(string))
+		if err != nil {
+			return nil, err
+		}
+		filter.activeAt = &at
+	}
+
+	return filter, nil
+}
+
+// matchesStub reports whether the short representation of a maintenance window can match the filter.
+func (f *maintenanceWindowFilter) matchesStub(stub dynatraceConfigV1.EntityShortRepresentation) bool {
+	if len(f.id) != 0 && stub.Id != f.id {
+		return false
+	}
+
+	if len(f.name) != 0 && stub.GetName() != f.name {
+		return false
+	}
+
+	return true
+}
+
+func (f *maintenanceWindowFilter) matches(mw *dynatraceConfigV1.MaintenanceWindow) (bool, error) {
+	if len(f.mwType) != 0 && !strings.EqualFold(mw.Type, f.mwType) {
+		return false, nil
+	}
+
+	if len(f.scopeEntity) != 0 {
+		found := false
+		for _, entity := range mw.GetScope().Entities {
+			if entity == f.scopeEntity {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	if len(f.scopeMzID) != 0 {
+		found := false
+		for _, match := range mw.GetScope().Matches {
+			if match.GetMzId() == f.scopeMzID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	if f.activeAt != nil {
+		active, err := maintenanceWindowActiveAt(&mw.Schedule, *f.activeAt)
+		if err != nil {
+			return false, fmt.Errorf("maintenance window %s: %v", mw.GetId(), err)
+		}
+		if !active {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// findMaintenanceWindows lists the maintenance windows of the environment and
+// returns the full configuration of every window matching the filter.
+func findMaintenanceWindows(providerConf *ProviderConfiguration, filter *maintenanceWindowFilter) ([]dynatraceConfigV1.MaintenanceWindow, diag.Diagnostics) {
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	var diags diag.Diagnostics
+
+	stubs, resp, err := dynatraceConfigClientV1.MaintenanceWindowsApi.ListMaintenanceWindows(authConfigV1).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to get dynatrace maintenance windows", err, resp, nil)...)
+		return nil, diags
+	}
+
+	maintenanceWindows := []dynatraceConfigV1.MaintenanceWindow{}
+
+	for _, stub := range stubs.Values {
+		if !filter.matchesStub(stub) {
+			continue
+		}
+
+		mw, resp, err := dynatraceConfigClientV1.MaintenanceWindowsApi.GetMaintenanceWindow(authConfigV1, stub.Id).Execute()
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace maintenance window", err, resp, nil)...)
+			return nil, diags
+		}
+
+		ok, err := filter.matches(&mw)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to evaluate maintenance window schedule",
+				Detail:   err.Error(),
+			})
+			continue
+		}
+
+		if ok {
+			if mw.Id == nil {
+				mw.SetId(stub.Id)
+			}
+			maintenanceWindows = append(maintenanceWindows, mw)
+		}
+	}
+
+	return maintenanceWindows, diags
+}
+
+// flattenMaintenanceWindowData maps a maintenance window onto the attributes of maintenanceWindowDataSchema.
+func flattenMaintenanceWindowData(mw *dynatraceConfigV1.MaintenanceWindow) map[string]interface{} {
+	scope := []interface{}{}
+	if mw.Scope != nil {
+		scope = flattenMaintenanceWindowScopeData(mw.Scope)
+	}
+
+	return map[string]interface{}{
+		"name":        mw.Name,
+		"description": mw.Description,
+		"type":        mw.Type,
+		"suppression": mw.Suppression,
+		"scope":       scope,
+		"schedule":    flattenMaintenanceWindowScheduleData(&mw.Schedule),
+	}
+}
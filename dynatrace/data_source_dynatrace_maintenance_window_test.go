@@ -0,0 +1,79 @@
+package dynatrace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDynatraceDataSourceMaintenanceWindow_basic(t *testing.T) {
+	name := "Test Maintenance Window"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceDataSourceMaintenanceWindowBasic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("dynatrace_maintenance_window.test", "name", name),
+				),
+			},
+			{
+				Config: testAccDynatraceDataSourceMaintenanceWindowBasic(name) +
+					testAccDynatraceDataSourceMaintenanceWindowRead(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dynatrace_maintenance_window.test", "name", name),
+					resource.TestCheckResourceAttr("data.dynatrace_maintenance_window.test", "schedule.0.recurrence.0.day_of_week", "FRIDAY"),
+					resource.TestCheckResourceAttr("data.dynatrace_maintenance_windows.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.dynatrace_maintenance_windows.test", "maintenance_windows.0.name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccDynatraceDataSourceMaintenanceWindowBasic(name string) string {
+	return fmt.Sprintf(`resource "dynatrace_maintenance_window" "test" {
+		name = "%s"
+		description = "Weekly update of windows servers"
+		type = "PLANNED"
+		suppression = "DETECT_PROBLEMS_DONT_ALERT"
+		scope {
+		  match {
+			type = "HOST"
+			tags {
+			  context = "CONTEXTLESS"
+			  key = "OS"
+			  value = "windows"
+			}
+		  }
+		}
+		schedule {
+		  recurrence_type = "WEEKLY"
+		  recurrence {
+			day_of_week = "FRIDAY"
+			start_time = "19:00"
+			duration_minutes = 60
+		  }
+		  start = "2021-01-01 00:00"
+		  end = "2030-01-01 00:00"
+		  zone_id = "UTC"
+		}
+	  }
+`, name)
+}
+
+func testAccDynatraceDataSourceMaintenanceWindowRead() string {
+	return fmt.Sprintf(`data "dynatrace_maintenance_window" "test" {
+    	name = "${dynatrace_maintenance_window.test.name}"
+}
+
+data "dynatrace_maintenance_windows" "test" {
+    	name      = "${dynatrace_maintenance_window.test.name}"
+    	type      = "PLANNED"
+    	active_at = "2021-01-01T19:30:00Z"
+}
+`)
+}
@@ -38,13 +38,9 @@ func dataSourceDynatraceManagementZoneRead(ctx context.Context, d *schema.Resour
 	id, idOk := d.GetOk("id")
 	name, nameOk := d.GetOk("name")
 
-	managementZones, _, err := dynatraceConfigClientV1.ManagementZonesApi.ListManagementZones(authConfigV1).Execute()
+	managementZones, resp, err := dynatraceConfigClientV1.ManagementZonesApi.ListManagementZones(authConfigV1).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to get dynatrace management zones",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to get dynatrace management zones", err, resp, nil)...)
 		return diags
 	}
 
@@ -0,0 +1,247 @@
+package dynatrace
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDynatraceSettingsObjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDynatraceSettingsObjectsRead,
+		Schema: map[string]*schema.Schema{
+			"schema_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Only match objects of these schemas, for example builtin:alerting.profile.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"scopes": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only match objects targeting one of these scopes, for example environment or the ID of a host.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"filter": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only match objects whose value matches this filter expression of the settings API, for example name = 'Default'.",
+			},
+			"ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the matching objects.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"objects": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching objects.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the object. Use it to import the object into a dynatrace_settings_object resource.",
+						},
+						"schema_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The schema on which the object is based.",
+						},
+						"schema_version": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the schema on which the object is based.",
+						},
+						"scope": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The scope that the object targets.",
+						},
+						"summary": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A short summary of the settings.",
+						},
+						"value": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value of the setting as JSON.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDynatraceSettingsObjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	settingsObjects, diags := findSettingsObjects(m.(*ProviderConfiguration), expandSettingsObjectsFilter(d))
+	if diags.HasError() {
+		return diags
+	}
+
+	sort.Slice(settingsObjects, func(i, j int) bool {
+		return settingsObjects[i].ObjectID < settingsObjects[j].ObjectID
+	})
+
+	ids := make([]string, len(settingsObjects))
+	sos := make([]interface{}, len(settingsObjects))
+
+	for i, so := range settingsObjects {
+		ids[i] = so.ObjectID
+
+		value := "{}"
+		if len(so.Value) != 0 {
+			contents, err := normalizeJSON(string(so.Value))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			value = contents
+		}
+
+		sos[i] = map[string]interface{}{
+			"id":             so.ObjectID,
+			"schema_id":      so.SchemaID,
+			"schema_version": so.SchemaVersion,
+			"scope":          so.Scope,
+			"summary":        so.Summary,
+			"value":          value,
+		}
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("objects", sos); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha1.Sum([]byte(strings.Join(ids, ",")))))
+
+	return diags
+}
+
+// settingsObjectsFilter holds the criteria settings objects are narrowed down by.
+type settingsObjectsFilter struct {
+	schemaIDs []string
+	scopes    []string
+	filter    string
+}
+
+func expandSettingsObjectsFilter(d *schema.ResourceData) *settingsObjectsFilter {
+	filter := &settingsObjectsFilter{}
+
+	for _, schemaID := range d.Get("schema_ids").([]interface{}) {
+		filter.schemaIDs = append(filter.schemaIDs, schemaID.(string))
+	}
+
+	for _, scope := range d.Get("scopes").([]interface{}) {
+		filter.scopes = append(filter.scopes, scope.(string))
+	}
+
+	if f, ok := d.GetOk("filter"); ok {
+		filter.filter = f.(string)
+	}
+
+	return filter
+}
+
+// query returns the query of the first page of settings objects matching the filter.
+func (f *settingsObjectsFilter) query() string {
+	query := url.Values{}
+	query.Set("schemaIds", strings.Join(f.schemaIDs, ","))
+	if len(f.scopes) != 0 {
+		query.Set("scopes", strings.Join(f.scopes, ","))
+	}
+	if len(f.filter) != 0 {
+		query.Set("filter", f.filter)
+	}
+	query.Set("fields", "objectId,schemaId,schemaVersion,scope,summary,value")
+	query.Set("pageSize", "500")
+	return query.Encode()
+}
+
+// foundSettingsObject is a settings object listed or read from the API. The value
+// is kept as received, the generated client would turn its numbers into floats.
+type foundSettingsObject struct {
+	ObjectID      string          `json:"objectId"`
+	SchemaID      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion"`
+	Scope         string          `json:"scope"`
+	Summary       string          `json:"summary"`
+	Value         json.RawMessage `json:"value"`
+}
+
+// findSettingsObjects lists the settings objects matching the filter, page by page.
+func findSettingsObjects(providerConf *ProviderConfiguration, filter *settingsObjectsFilter) ([]foundSettingsObject, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	settingsObjects := []foundSettingsObject{}
+	err := listPages(func(nextPageKey string) (*string, error) {
+		query := filter.query()
+		if len(nextPageKey) != 0 {
+			query = url.Values{"nextPageKey": []string{nextPageKey}}.Encode()
+		}
+
+		response, err := restRequest(providerConf.AuthEnvironmentV2, providerConf.environmentV2URL, http.MethodGet, "/settings/objects?"+query, nil)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to get settings objects", err, nil, nil)...)
+			return nil, err
+		}
+
+		var page struct {
+			Items       []foundSettingsObject `json:"items"`
+			NextPageKey *string               `json:"nextPageKey"`
+		}
+		if err := json.Unmarshal(response, &page); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to get settings objects",
+				Detail:   err.Error(),
+			})
+			return nil, err
+		}
+
+		settingsObjects = append(settingsObjects, page.Items...)
+
+		return page.NextPageKey, nil
+	})
+	if err != nil {
+		return nil, diags
+	}
+
+	return settingsObjects, diags
+}
+
+// getSettingsObject reads the settings object with the ID.
+func getSettingsObject(providerConf *ProviderConfiguration, objectID string) (*foundSettingsObject, error) {
+	response, err := restRequest(providerConf.AuthEnvironmentV2, providerConf.environmentV2URL, http.MethodGet, "/settings/objects/"+url.PathEscape(objectID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	settingsObject := &foundSettingsObject{}
+	if err := json.Unmarshal(response, settingsObject); err != nil {
+		return nil, err
+	}
+
+	return settingsObject, nil
+}
@@ -0,0 +1,71 @@
+package dynatrace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDynatraceDataSourceSettingsObjects_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceSettingsObjectConfig(name, "ERROR"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceSettingsObjectExists("dynatrace_settings_object.test"),
+				),
+			},
+			{
+				Config: testAccDynatraceSettingsObjectConfig(name, "ERROR") +
+					testAccDynatraceDataSourceSettingsObjectsRead(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dynatrace_settings_objects.test", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.dynatrace_settings_objects.test", "objects.0.id", "dynatrace_settings_object.test", "id"),
+					resource.TestCheckResourceAttr("data.dynatrace_settings_objects.test", "objects.0.scope", "environment"),
+					resource.TestCheckResourceAttrSet("data.dynatrace_settings_objects.test", "objects.0.value"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDynatraceDataSourceSettingsObjectsRead() string {
+	return `data "dynatrace_settings_objects" "test" {
+	schema_ids = [dynatrace_settings_object.test.schema_id]
+	scopes     = ["environment"]
+	filter     = "name = '${jsondecode(dynatrace_settings_object.test.value).name}'"
+}
+`
+}
+
+func TestSettingsObjectsFilterQuery(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          settingsObjectsFilter
+		ExpectedOutput string
+	}{
+		{
+			"schemas",
+			settingsObjectsFilter{schemaIDs: []string{"builtin:alerting.profile", "builtin:alerting.maintenance-window"}},
+			"fields=objectId%2CschemaId%2CschemaVersion%2Cscope%2Csummary%2Cvalue&pageSize=500&schemaIds=builtin%3Aalerting.profile%2Cbuiltin%3Aalerting.maintenance-window",
+		},
+		{
+			"scopes and filter",
+			settingsObjectsFilter{schemaIDs: []string{"builtin:alerting.profile"}, scopes: []string{"environment"}, filter: "name = 'Ops'"},
+			"fields=objectId%2CschemaId%2CschemaVersion%2Cscope%2Csummary%2Cvalue&filter=name+%3D+%27Ops%27&pageSize=500&schemaIds=builtin%3Aalerting.profile&scopes=environment",
+		},
+	}
+
+	for _, c := range cases {
+		output := c.Input.query()
+		if output != c.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %s\nGiven:    %s", c.Name, c.ExpectedOutput, output)
+		}
+	}
+}
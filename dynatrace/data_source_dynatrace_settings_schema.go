@@ -0,0 +1,230 @@
+package dynatrace
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDynatraceSettingsSchema() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDynatraceSettingsSchemaRead,
+		Schema: map[string]*schema.Schema{
+			"schema_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the schema, for example builtin:alerting.profile.",
+			},
+			"version": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The version of the schema. The latest version is read if not set.",
+			},
+			"display_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The display name of the schema.",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A short description of the schema.",
+			},
+			"multi_object": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether a scope can hold several objects of the schema.",
+			},
+			"ordered": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the order of the objects of the schema matters.",
+			},
+			"max_objects": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of objects per scope.",
+			},
+			"allowed_scopes": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The scopes objects of the schema can target, environment or entity types like HOST.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"property": settingsSchemaPropertiesSchema("The properties of the objects of the schema."),
+			"type": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The types of nested objects referenced by properties.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the type.",
+						},
+						"display_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The display name of the type.",
+						},
+						"property": settingsSchemaPropertiesSchema("The properties of the type."),
+					},
+				},
+			},
+			"enum": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The enums referenced by properties.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the enum.",
+						},
+						"display_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The display name of the enum.",
+						},
+						"values": &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The values of the enum.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// settingsSchemaPropertiesSchema returns the attributes describing the properties
+// of a schema or of one of its types.
+func settingsSchemaPropertiesSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the property in the value of an object.",
+				},
+				"display_name": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The display name of the property.",
+				},
+				"description": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "A short description of the property.",
+				},
+				"type": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The type of the property, like text, integer or list. Properties holding an enum value are of type enum, those holding a nested object of type setting.",
+				},
+				"reference": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the enum or type of enum and setting properties.",
+				},
+				"items_type": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The type of the elements of list and set properties.",
+				},
+				"items_reference": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the enum or type of the elements of list and set properties.",
+				},
+				"nullable": &schema.Schema{
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the property can be left out.",
+				},
+				"default": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The default value of the property as JSON.",
+				},
+				"constraint": &schema.Schema{
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "The constraints on the value of the property or on its elements.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": &schema.Schema{
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "The type of the constraint, like LENGTH, RANGE, PATTERN or NOT_BLANK.",
+							},
+							"min_length": &schema.Schema{
+								Type:        schema.TypeInt,
+								Computed:    true,
+								Description: "The minimum length of LENGTH constraints.",
+							},
+							"max_length": &schema.Schema{
+								Type:        schema.TypeInt,
+								Computed:    true,
+								Description: "The maximum length of LENGTH constraints.",
+							},
+							"minimum": &schema.Schema{
+								Type:        schema.TypeFloat,
+								Computed:    true,
+								Description: "The minimum of RANGE constraints.",
+							},
+							"maximum": &schema.Schema{
+								Type:        schema.TypeFloat,
+								Computed:    true,
+								Description: "The maximum of RANGE constraints.",
+							},
+							"pattern": &schema.Schema{
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "The regular expression of PATTERN constraints.",
+							},
+							"custom_message": &schema.Schema{
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "The message shown when the constraint is violated.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDynatraceSettingsSchemaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	schemaID := d.Get("schema_id").(string)
+
+	s, err := fetchSettingsSchema(providerConf, schemaID, d.Get("version").(string))
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read settings schema", err, nil, nil)...)
+		return diags
+	}
+
+	d.SetId(s.SchemaID + "@" + s.Version)
+
+	return flattenSettingsSchema(s, d)
+}
@@ -0,0 +1,35 @@
+package dynatrace
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDynatraceDataSourceSettingsSchema_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceDataSourceSettingsSchemaRead(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.dynatrace_settings_schema.test", "schema_id", "builtin:alerting.profile"),
+					resource.TestCheckResourceAttrSet("data.dynatrace_settings_schema.test", "version"),
+					resource.TestCheckResourceAttr("data.dynatrace_settings_schema.test", "multi_object", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.dynatrace_settings_schema.test", "property.*", map[string]string{
+						"name": "name",
+						"type": "text",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDynatraceDataSourceSettingsSchemaRead() string {
+	return `data "dynatrace_settings_schema" "test" {
+	schema_id = "builtin:alerting.profile"
+}
+`
+}
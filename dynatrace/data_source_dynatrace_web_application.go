@@ -37,13 +37,9 @@ func dataSourceDynatraceWebApplicationRead(ctx context.Context, d *schema.Resour
 	id, idOk := d.GetOk("id")
 	name, nameOk := d.GetOk("name")
 
-	webApplications, _, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.ListWebApplicationConfigs(authConfigV1).Execute()
+	webApplications, resp, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.ListWebApplicationConfigs(authConfigV1).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to get dynatrace web applications",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to get dynatrace web applications", err, resp, nil)...)
 		return diags
 	}
 
@@ -0,0 +1,205 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	dynatraceClusterV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/cluster/dynatrace"
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	dynatraceClusterV2 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/cluster/dynatrace"
+	dynatraceEnvironmentV2 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/environment/dynatrace"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiError is an error returned by one of the API clients or by restRequest,
+// decoded into the request that failed and the error body of the response.
+type apiError struct {
+	method     string
+	url        string
+	status     string
+	message    string
+	violations []configV1ConstraintViolation
+}
+
+// decodeAPIError decodes an error returned by the API clients. The response, if
+// given, names the request that failed, the generated clients don't keep it in
+// their errors.
+func decodeAPIError(err error, resp *http.Response) *apiError {
+	e := &apiError{message: err.Error()}
+
+	var body []byte
+
+	switch t := err.(type) {
+	case RESTError:
+		e.method = t.Method
+		e.url = t.URL
+		e.status = t.Status
+		body = t.Body()
+	case dynatraceConfigV1.GenericOpenAPIError:
+		e.status = t.Error()
+		body = t.Body()
+	case dynatraceClusterV1.GenericOpenAPIError:
+		e.status = t.Error()
+		body = t.Body()
+	case dynatraceClusterV2.GenericOpenAPIError:
+		e.status = t.Error()
+		body = t.Body()
+	case dynatraceEnvironmentV2.GenericOpenAPIError:
+		e.status = t.Error()
+		body = t.Body()
+	case *url.Error:
+		e.method = strings.ToUpper(t.Op)
+		e.url = t.URL
+		e.message = t.Err.Error()
+	}
+
+	if resp != nil {
+		e.status = resp.Status
+		if resp.Request != nil {
+			e.method = resp.Request.Method
+			e.url = resp.Request.URL.String()
+		}
+	}
+
+	if len(body) != 0 {
+		if apiErr, ok := decodeAPIErrorBody(body); ok {
+			e.message = apiErr.Message
+			e.violations = apiErr.ConstraintViolations
+		} else {
+			e.message = strings.TrimSpace(string(body))
+		}
+	}
+
+	return e
+}
+
+// decodeAPIErrorBody decodes an error response of the config, cluster and
+// environment APIs, which share the same structure. The settings API responds
+// to requests for several objects with one such response per object, the first
+// one with an error is returned.
+func decodeAPIErrorBody(body []byte) (*configV1Error, bool) {
+	var responses []configV1ErrorResponse
+	if err := json.Unmarshal(body, &responses); err != nil {
+		var response configV1ErrorResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, false
+		}
+		responses = []configV1ErrorResponse{response}
+	}
+
+	for _, response := range responses {
+		if len(response.Error.Message) != 0 || len(response.Error.ConstraintViolations) != 0 {
+			return &response.Error, true
+		}
+	}
+	return nil, false
+}
+
+// request describes the request that failed, for example
+// POST https://example.live.dynatrace.com/api/config/v1/autoTags: 400 Bad Request.
+func (e *apiError) request() string {
+	switch {
+	case len(e.method) != 0 && len(e.status) != 0:
+		return fmt.Sprintf("%s %s: %s", e.method, e.url, e.status)
+	case len(e.method) != 0:
+		return fmt.Sprintf("%s %s", e.method, e.url)
+	default:
+		return e.status
+	}
+}
+
+// detail returns the message, the constraint violations and the request.
+func (e *apiError) detail(violations []configV1ConstraintViolation) string {
+	lines := []string{}
+
+	if len(e.message) != 0 && e.message != e.status {
+		lines = append(lines, e.message)
+	}
+
+	for _, violation := range violations {
+		if len(violation.Path) != 0 {
+			lines = append(lines, fmt.Sprintf("  - %s: %s", violation.Path, violation.Message))
+		} else {
+			lines = append(lines, "  - "+violation.Message)
+		}
+	}
+
+	if request := e.request(); len(request) != 0 {
+		if len(lines) != 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, request)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// apiErrorDiagnostics turns an error returned by the API clients into
+// diagnostics. Each constraint violation of a request body that matches an
+// attribute of the schema gets its own diagnostic pointing at that attribute,
+// everything else is reported in a single diagnostic. The schema may be nil for
+// requests without a body.
+func apiErrorDiagnostics(summary string, err error, resp *http.Response, s map[string]*schema.Schema) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	e := decodeAPIError(err, resp)
+
+	var violations []configV1ConstraintViolation
+
+	for _, violation := range e.violations {
+		attribute := ""
+		if s != nil && (violation.ParameterLocation == "" || violation.ParameterLocation == "PAYLOAD_BODY") {
+			attribute = attributePathFromAPI(s, violation.Path)
+		}
+
+		if len(attribute) == 0 {
+			violations = append(violations, violation)
+			continue
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        fmt.Sprintf("%s\n\n%s", violation.Message, e.request()),
+			AttributePath: attributeCtyPath(attribute),
+		})
+	}
+
+	if len(diags) == 0 || len(violations) != 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   e.detail(violations),
+		})
+	}
+
+	return diags
+}
+
+// attributeCtyPath converts an attribute path like rule.0.condition.1 into a cty.Path.
+func attributeCtyPath(attribute string) cty.Path {
+	var path cty.Path
+
+	for _, step := range strings.Split(attribute, ".") {
+		if i, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(i)
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+
+	return path
+}
+
+// getErrorMessage describes an error returned by the API clients in a single
+// message, with the constraint violations and the request that failed.
+func getErrorMessage(err error) string {
+	e := decodeAPIError(err, nil)
+	return e.detail(e.violations)
+}
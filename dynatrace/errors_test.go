@@ -0,0 +1,88 @@
+package dynatrace
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestApiErrorDiagnostics(t *testing.T) {
+	err := RESTError{
+		Method:     "PUT",
+		URL:        "https://example.live.dynatrace.com/api/config/v1/managementZones/1",
+		Status:     "400 Bad Request",
+		StatusCode: 400,
+		body: []byte(`{"error":{"code":400,"message":"Constraints violated.","constraintViolations":[
+			{"path":"rules[0].conditions[1].comparisonInfo.value","message":"must not be null","parameterLocation":"PAYLOAD_BODY"},
+			{"path":"id","message":"must match the path","parameterLocation":"PATH"}
+		]}}`),
+	}
+
+	diags := apiErrorDiagnostics("Unable to update dynatrace management zone", err, nil, resourceDynatraceManagementZone().Schema)
+
+	expected := diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       "Unable to update dynatrace management zone",
+			Detail:        "must not be null\n\nPUT https://example.live.dynatrace.com/api/config/v1/managementZones/1: 400 Bad Request",
+			AttributePath: cty.GetAttrPath("rule").IndexInt(0).GetAttr("condition").IndexInt(1).GetAttr("comparison_info").IndexInt(0).GetAttr("value"),
+		},
+		{
+			Severity: diag.Error,
+			Summary:  "Unable to update dynatrace management zone",
+			Detail:   "Constraints violated.\n  - id: must match the path\n\nPUT https://example.live.dynatrace.com/api/config/v1/managementZones/1: 400 Bad Request",
+		},
+	}
+	if !reflect.DeepEqual(diags, expected) {
+		t.Fatalf("Unexpected diagnostics.\nExpected: %#v\nGiven:    %#v", expected, diags)
+	}
+}
+
+func TestGetErrorMessage(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          error
+		ExpectedOutput string
+	}{
+		{
+			"body",
+			RESTError{
+				Method: "GET",
+				URL:    "https://example.live.dynatrace.com/api/config/v1/dashboards/1",
+				Status: "404 Not Found",
+				body:   []byte(`{"error":{"code":404,"message":"Dashboard 1 not found"}}`),
+			},
+			"Dashboard 1 not found\n\nGET https://example.live.dynatrace.com/api/config/v1/dashboards/1: 404 Not Found",
+		},
+		{
+			"settings",
+			RESTError{
+				Method: "POST",
+				URL:    "https://example.live.dynatrace.com/api/v2/settings/objects",
+				Status: "400 Bad Request",
+				body:   []byte(`[{"code":400,"error":{"code":400,"message":"Validation failed","constraintViolations":[{"path":"name","message":"must not be empty"}]},"invalidValue":{}}]`),
+			},
+			"Validation failed\n  - name: must not be empty\n\nPOST https://example.live.dynatrace.com/api/v2/settings/objects: 400 Bad Request",
+		},
+		{
+			"url",
+			&url.Error{Op: "Get", URL: "https://example.live.dynatrace.com/api/config/v1/autoTags", Err: errors.New("connection refused")},
+			"connection refused\n\nGET https://example.live.dynatrace.com/api/config/v1/autoTags",
+		},
+		{
+			"other",
+			errors.New("unexpected end of JSON input"),
+			"unexpected end of JSON input",
+		},
+	}
+	for _, tc := range cases {
+		output := getErrorMessage(tc.Input)
+		if output != tc.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %q\nGiven:    %q", tc.Name, tc.ExpectedOutput, output)
+		}
+	}
+}
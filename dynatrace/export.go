@@ -0,0 +1,390 @@
+package dynatrace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// exportedResources are the resource types written by Export, in the order they are exported.
+var exportedResources = []struct {
+	resourceType string
+	resource     func() *schema.Resource
+	list         func(ctx context.Context, m interface{}) ([]namedEntity, error)
+}{
+	{"dynatrace_management_zone", resourceDynatraceManagementZone, resourceDynatraceManagementZoneNames},
+	{"dynatrace_auto_tag", resourceDynatraceAutoTag, resourceDynatraceAutoTagNames},
+	{"dynatrace_alerting_profile", resourceDynatraceAlertingProfile, resourceDynatraceAlertingProfileNames},
+	{"dynatrace_notification", resourceDynatraceNotification, resourceDynatraceNotificationNames},
+	{"dynatrace_maintenance_window", resourceDynatraceMaintenanceWindow, resourceDynatraceMaintenanceWindowNames},
+	{"dynatrace_dashboard", resourceDynatraceDashboard, resourceDynatraceDashboardNames},
+	{"dynatrace_web_application", resourceDynatraceWebApplication, resourceDynatraceWebApplicationNames},
+	{"dynatrace_application_detection_rule", resourceDynatraceApplicationDetectionRule, resourceDynatraceApplicationDetectionRuleNames},
+}
+
+// exportedEntity is an entity read from the environment, named by its resource address.
+type exportedEntity struct {
+	resourceType string
+	name         string
+	id           string
+	schema       map[string]*schema.Schema
+	data         *schema.ResourceData
+}
+
+func (e *exportedEntity) address() string {
+	return e.resourceType + "." + e.name
+}
+
+// Export writes the configuration of an environment as .tf files, one per resource type,
+// plus an imports.tf file with the import blocks that take the entities over.
+// The provider is configured by the DYNATRACE_* environment variables.
+func Export(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: terraform-provider-dynatrace export [-dir <directory>] [resource types...]\n\n")
+		flags.PrintDefaults()
+	}
+	dir := flags.String("dir", ".", "directory to write the .tf files to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	resourceTypes := flags.Args()
+	for _, resourceType := range resourceTypes {
+		if !exportsResourceType(resourceType) {
+			return fmt.Errorf("%s can't be exported", resourceType)
+		}
+	}
+
+	p := Provider()
+	if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{})); diags.HasError() {
+		return diagnosticsError(diags)
+	}
+
+	entities, err := exportEntities(ctx, p.Meta(), resourceTypes)
+	if err != nil {
+		return err
+	}
+
+	return writeExport(*dir, entities)
+}
+
+func exportsResourceType(resourceType string) bool {
+	for _, r := range exportedResources {
+		if r.resourceType == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+func exportEntities(ctx context.Context, m interface{}, resourceTypes []string) ([]*exportedEntity, error) {
+	entities := []*exportedEntity{}
+
+	for _, r := range exportedResources {
+		if len(resourceTypes) != 0 && !conditionContains(resourceTypes, r.resourceType) {
+			continue
+		}
+
+		listed, err := r.list(ctx, m)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list %s: %s", r.resourceType, getErrorMessage(err))
+		}
+
+		// entities can be listed under several names, the first one is used
+		seen := map[string]bool{}
+		names := map[string]bool{}
+		for _, e := range listed {
+			if seen[e.id] {
+				continue
+			}
+			seen[e.id] = true
+
+			resource := r.resource()
+			d := resource.Data(nil)
+			d.SetId(e.id)
+
+			if diags := resource.ReadContext(ctx, d, m); diags.HasError() {
+				fmt.Fprintf(os.Stderr, "Skipping %s %s: %s\n", r.resourceType, e.id, diagnosticsError(diags))
+				continue
+			}
+			// deleted since it was listed
+			if d.Id() == "" {
+				continue
+			}
+
+			entities = append(entities, &exportedEntity{
+				resourceType: r.resourceType,
+				name:         exportName(e.name, names),
+				id:           e.id,
+				schema:       resource.Schema,
+				data:         d,
+			})
+		}
+	}
+
+	return entities, nil
+}
+
+func writeExport(dir string, entities []*exportedEntity) error {
+	// IDs of exported entities are replaced by references wherever other entities use them
+	references := map[string]string{}
+	for _, e := range entities {
+		references[e.id] = e.address() + ".id"
+	}
+
+	files := map[string]*bytes.Buffer{}
+	imports := &bytes.Buffer{}
+
+	for _, e := range entities {
+		file := e.resourceType + ".tf"
+		if files[file] == nil {
+			files[file] = &bytes.Buffer{}
+		} else {
+			files[file].WriteString("\n")
+		}
+
+		fmt.Fprintf(files[file], "resource %s %s {\n", hclString(e.resourceType), hclString(e.name))
+		writeHCLBody(files[file], "  ", e.schema, exportValues(e.schema, e.data), references)
+		files[file].WriteString("}\n")
+
+		if imports.Len() != 0 {
+			imports.WriteString("\n")
+		}
+		fmt.Fprintf(imports, "import {\n  to = %s\n  id = %s\n}\n", e.address(), hclString(e.id))
+	}
+
+	files["imports.tf"] = imports
+
+	for file, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), content.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportValues returns the arguments Read set. The data isn't planned, so schema
+// defaults aren't applied to the ones it didn't set and Get would return their
+// zero values instead.
+func exportValues(s map[string]*schema.Schema, d *schema.ResourceData) map[string]interface{} {
+	values := map[string]interface{}{}
+	for k := range s {
+		if v, ok := d.GetOkExists(k); ok {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+// writeHCLBody writes the arguments of a resource or block, attributes first and
+// aligned like terraform fmt does, nested blocks after them.
+func writeHCLBody(w *bytes.Buffer, indent string, s map[string]*schema.Schema, values map[string]interface{}, references map[string]string) {
+	attributes := []string{}
+	blocks := []string{}
+	for k, v := range s {
+		if exportSkipsValue(k, v, values[k]) {
+			continue
+		}
+		if _, ok := v.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+		} else {
+			attributes = append(attributes, k)
+		}
+	}
+	sort.Strings(attributes)
+	sort.Strings(blocks)
+
+	width := 0
+	for _, k := range attributes {
+		if len(k) > width && !s[k].Sensitive {
+			width = len(k)
+		}
+	}
+
+	for _, k := range attributes {
+		if s[k].Sensitive {
+			fmt.Fprintf(w, "%s# %s is sensitive and is not exported\n", indent, k)
+			continue
+		}
+		fmt.Fprintf(w, "%s%-*s = %s\n", indent, width, k, hclValue(indent, values[k], references))
+	}
+
+	for i, k := range blocks {
+		elem := s[k].Elem.(*schema.Resource)
+		for j, element := range exportList(values[k]) {
+			if i != 0 || j != 0 || len(attributes) != 0 {
+				w.WriteString("\n")
+			}
+			fmt.Fprintf(w, "%s%s {\n", indent, k)
+			if m, ok := element.(map[string]interface{}); ok {
+				writeHCLBody(w, indent+"  ", elem.Schema, m, references)
+			}
+			fmt.Fprintf(w, "%s}\n", indent)
+		}
+	}
+}
+
+// exportSkipsValue reports whether an argument is left out of the configuration,
+// because it can't be configured, because it isn't set or because it has its
+// default value.
+func exportSkipsValue(k string, s *schema.Schema, value interface{}) bool {
+	if k == "id" || (s.Computed && !s.Optional) || s.Deprecated != "" || value == nil {
+		return true
+	}
+	if s.Required {
+		return false
+	}
+	if s.Default != nil {
+		return reflect.DeepEqual(s.Default, value)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	default:
+		return len(exportList(value)) == 0
+	}
+}
+
+func exportList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		return []interface{}{v}
+	}
+	return nil
+}
+
+func hclValue(indent string, value interface{}, references map[string]string) string {
+	switch v := value.(type) {
+	case string:
+		if reference, ok := references[v]; ok {
+			return reference
+		}
+		return hclString(v)
+	case []interface{}, *schema.Set:
+		elements := []string{}
+		for _, element := range exportList(v) {
+			elements = append(elements, hclValue(indent, element, references))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		b := strings.Builder{}
+		b.WriteString("{\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s  %s = %s\n", indent, hclString(k), hclValue(indent+"  ", v[k], references))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// hclString quotes a string, escaping template sequences so they are taken literally.
+func hclString(s string) string {
+	b := strings.Builder{}
+	b.WriteString(`"`)
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString(`"`)
+	return b.String()
+}
+
+// exportName turns the name of an entity into a resource name that isn't taken yet.
+func exportName(name string, taken map[string]bool) string {
+	b := strings.Builder{}
+	separate := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if separate && b.Len() != 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+			separate = false
+		} else {
+			separate = true
+		}
+	}
+
+	base := b.String()
+	// resource names start with a letter or an underscore
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "_" + base
+	}
+
+	result := base
+	for i := 2; taken[result]; i++ {
+		result = fmt.Sprintf("%s_%d", base, i)
+	}
+	taken[result] = true
+
+	return result
+}
+
+func diagnosticsError(diags diag.Diagnostics) error {
+	messages := []string{}
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		if d.Detail != "" {
+			messages = append(messages, d.Summary+": "+d.Detail)
+		} else {
+			messages = append(messages, d.Summary)
+		}
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
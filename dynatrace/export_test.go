@@ -0,0 +1,96 @@
+package dynatrace
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExportName(t *testing.T) {
+	taken := map[string]bool{}
+
+	cases := []struct {
+		Name           string
+		Input          string
+		ExpectedOutput string
+	}{
+		{"simple", "Production", "production"},
+		{"separators", "Ops team - EMEA", "ops_team_emea"},
+		{"taken", "ops-team EMEA", "ops_team_emea_2"},
+		{"digit", "2nd level", "_2nd_level"},
+		{"empty", "🚀", "_"},
+	}
+	for _, tc := range cases {
+		output := exportName(tc.Input, taken)
+		if output != tc.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %s\nGiven:    %s", tc.Name, tc.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestHCLString(t *testing.T) {
+	output := hclString("say \"hi\"\n${name} and %{if} 100% $")
+	expected := `"say \"hi\"\n$${name} and %%{if} 100% $"`
+	if output != expected {
+		t.Fatalf("Unexpected output.\nExpected: %s\nGiven:    %s", expected, output)
+	}
+}
+
+func TestWriteHCLBody(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"display_name": &schema.Schema{Type: schema.TypeString, Required: true},
+			"mz_id":        &schema.Schema{Type: schema.TypeString, Optional: true},
+			"enabled":      &schema.Schema{Type: schema.TypeBool, Optional: true, Default: true},
+			"description":  &schema.Schema{Type: schema.TypeString, Optional: true},
+			"occurrences":  &schema.Schema{Type: schema.TypeInt, Optional: true, Default: 5},
+			"retries":      &schema.Schema{Type: schema.TypeInt, Optional: true, Default: 3},
+			"password":     &schema.Schema{Type: schema.TypeString, Optional: true, Sensitive: true},
+			"created":      &schema.Schema{Type: schema.TypeString, Computed: true},
+			"rule": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"severity_level": &schema.Schema{Type: schema.TypeString, Required: true},
+						"tags":           &schema.Schema{Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+					},
+				},
+			},
+		},
+	}
+
+	d := r.Data(nil)
+	d.Set("display_name", "Ops ${team}")
+	d.Set("mz_id", "-123")
+	d.Set("enabled", true)
+	d.Set("retries", 0)
+	d.Set("password", "secret")
+	d.Set("created", "yesterday")
+	d.Set("rule", []interface{}{
+		map[string]interface{}{"severity_level": "AVAILABILITY", "tags": []interface{}{"env", "team"}},
+		map[string]interface{}{"severity_level": "ERROR"},
+	})
+
+	w := &bytes.Buffer{}
+	writeHCLBody(w, "  ", r.Schema, exportValues(r.Schema, d), map[string]string{"-123": "dynatrace_management_zone.production.id"})
+
+	expected := `  display_name = "Ops $${team}"
+  mz_id        = dynatrace_management_zone.production.id
+  # password is sensitive and is not exported
+  retries      = 0
+
+  rule {
+    severity_level = "AVAILABILITY"
+    tags           = ["env", "team"]
+  }
+
+  rule {
+    severity_level = "ERROR"
+  }
+`
+	if w.String() != expected {
+		t.Fatalf("Unexpected output.\nExpected:\n%s\nGiven:\n%s", expected, w.String())
+	}
+}
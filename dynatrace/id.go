@@ -0,0 +1,59 @@
+package dynatrace
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Entities of the config v1 API are created with a PUT under a random ID generated
+// by the provider, instead of a POST that lets the API pick one. The ID is set on
+// the resource before the request. If the request fails without a response that
+// rules out the entity was created, e.g. on a timeout, Terraform keeps the ID in
+// the state as tainted and the next apply replaces the entity instead of creating
+// a duplicate.
+
+// newUUID returns a random UUID (version 4) for an entity.
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16]), nil
+}
+
+// newNumericID returns a random positive 64-bit integer ID for an entity, for APIs
+// like the management zones one that don't use UUIDs.
+func newNumericID() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(int64(binary.BigEndian.Uint64(b[:])>>1), 10), nil
+}
+
+// createRejected reports whether a failed create request got a response that
+// rules out the entity was created. err and resp are those of restRequest or the
+// generated clients.
+func createRejected(err error, resp *http.Response) bool {
+	if restErr, ok := err.(RESTError); ok {
+		return restErr.StatusCode < http.StatusInternalServerError
+	}
+	return resp != nil && resp.StatusCode < http.StatusInternalServerError
+}
+
+// entityNotFound reports whether a failed request got a 404, the entity was
+// deleted outside of Terraform or never created.
+func entityNotFound(err error, resp *http.Response) bool {
+	if restErr, ok := err.(RESTError); ok {
+		return restErr.StatusCode == http.StatusNotFound
+	}
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
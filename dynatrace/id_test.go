@@ -0,0 +1,74 @@
+package dynatrace
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestNewUUID(t *testing.T) {
+	id, err := newUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(id) {
+		t.Fatalf("Expected a version 4 UUID, got %s", id)
+	}
+	if other, _ := newUUID(); other == id {
+		t.Fatalf("Expected different IDs, got %s twice", id)
+	}
+}
+
+func TestNewNumericID(t *testing.T) {
+	id, err := newNumericID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := strconv.ParseInt(id, 10, 64); err != nil || n < 0 {
+		t.Fatalf("Expected a positive 64-bit integer, got %s", id)
+	}
+}
+
+func TestCreateRejected(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Err            error
+		Resp           *http.Response
+		ExpectedOutput bool
+	}{
+		{
+			"constraint violation",
+			RESTError{StatusCode: http.StatusBadRequest},
+			nil,
+			true,
+		},
+		{
+			"server error",
+			RESTError{StatusCode: http.StatusServiceUnavailable},
+			nil,
+			false,
+		},
+		{
+			"generated client",
+			errors.New("400 Bad Request"),
+			&http.Response{StatusCode: http.StatusBadRequest},
+			true,
+		},
+		{
+			"no response",
+			errors.New("timeout"),
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		if output := createRejected(tc.Err, tc.Resp); output != tc.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %v\nGiven:    %v", tc.Name, tc.ExpectedOutput, output)
+		}
+	}
+}
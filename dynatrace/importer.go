@@ -0,0 +1,94 @@
+package dynatrace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importNamePrefix marks import IDs that hold the name of an entity instead of its ID.
+const importNamePrefix = "name="
+
+// namedEntity is an entity returned by one of the List APIs, used to resolve the
+// ID of an entity imported by name.
+type namedEntity struct {
+	id   string
+	name string
+}
+
+// importStateByName returns an importer that accepts either the ID of an entity or
+// "name=<name>", in which case the ID is looked up in the entities returned by list.
+func importStateByName(description string, list func(ctx context.Context, m interface{}) ([]namedEntity, error)) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			if !strings.HasPrefix(d.Id(), importNamePrefix) {
+				return []*schema.ResourceData{d}, nil
+			}
+
+			entities, err := list(ctx, m)
+			if err != nil {
+				return nil, fmt.Errorf("unable to list %ss: %s", description, getErrorMessage(err))
+			}
+
+			id, err := resolveImportName(description, strings.TrimPrefix(d.Id(), importNamePrefix), entities)
+			if err != nil {
+				return nil, err
+			}
+
+			d.SetId(id)
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+func resolveImportName(description string, name string, entities []namedEntity) (string, error) {
+	// an entity may be listed under several names, e.g. notifications with and without their type
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, entity := range entities {
+		if entity.name == name && !seen[entity.id] {
+			seen[entity.id] = true
+			ids = append(ids, entity.id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q was found", description, name)
+	case 1:
+		return ids[0], nil
+	default:
+		sort.Strings(ids)
+		return "", fmt.Errorf("%d %ss are named %q, import one of them by ID instead: %s", len(ids), description, name, strings.Join(ids, ", "))
+	}
+}
+
+// findAdoptedEntity returns the ID of the entity with the name for adopt_existing,
+// false if there is none. Several entities with the name are an error, as for imports.
+func findAdoptedEntity(description string, name string, entities []namedEntity) (string, bool, error) {
+	for _, entity := range entities {
+		if entity.name == name {
+			id, err := resolveImportName(description, name, entities)
+			return id, err == nil, err
+		}
+	}
+
+	return "", false, nil
+}
+
+// namedEntitiesFromStubs converts the stubs returned by the List APIs of the config API.
+func namedEntitiesFromStubs(stubs []dynatraceConfigV1.EntityShortRepresentation) []namedEntity {
+	entities := make([]namedEntity, 0, len(stubs))
+	for _, stub := range stubs {
+		if stub.Name != nil {
+			entities = append(entities, namedEntity{id: stub.Id, name: *stub.Name})
+		}
+	}
+
+	return entities
+}
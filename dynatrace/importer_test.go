@@ -0,0 +1,109 @@
+package dynatrace
+
+import (
+	"testing"
+)
+
+func TestResolveImportName(t *testing.T) {
+	entities := []namedEntity{
+		{id: "1", name: "Production"},
+		{id: "2", name: "Staging"},
+		{id: "3", name: "Staging"},
+		{id: "4", name: "Ops team"},
+		{id: "4", name: "EMAIL/Ops team"},
+	}
+
+	cases := []struct {
+		Name          string
+		Input         string
+		ExpectedID    string
+		ExpectedError string
+	}{
+		{
+			"unique",
+			"Production",
+			"1",
+			"",
+		},
+		{
+			"listed twice",
+			"Ops team",
+			"4",
+			"",
+		},
+		{
+			"qualified",
+			"EMAIL/Ops team",
+			"4",
+			"",
+		},
+		{
+			"ambiguous",
+			"Staging",
+			"",
+			`2 management zones are named "Staging", import one of them by ID instead: 2, 3`,
+		},
+		{
+			"missing",
+			"Development",
+			"",
+			`no management zone named "Development" was found`,
+		},
+	}
+	for _, tc := range cases {
+		id, err := resolveImportName("management zone", tc.Input, entities)
+		if id != tc.ExpectedID {
+			t.Fatalf("%s: unexpected ID.\nExpected: %s\nGiven:    %s", tc.Name, tc.ExpectedID, id)
+		}
+		if (err == nil && tc.ExpectedError != "") || (err != nil && err.Error() != tc.ExpectedError) {
+			t.Fatalf("%s: unexpected error.\nExpected: %s\nGiven:    %v", tc.Name, tc.ExpectedError, err)
+		}
+	}
+}
+
+func TestFindAdoptedEntity(t *testing.T) {
+	entities := []namedEntity{
+		{id: "1", name: "Production"},
+		{id: "2", name: "Staging"},
+		{id: "3", name: "Staging"},
+	}
+
+	cases := []struct {
+		Name          string
+		Input         string
+		ExpectedID    string
+		ExpectedFound bool
+		ExpectedError string
+	}{
+		{
+			"unique",
+			"Production",
+			"1",
+			true,
+			"",
+		},
+		{
+			"ambiguous",
+			"Staging",
+			"",
+			false,
+			`2 auto tags are named "Staging", import one of them by ID instead: 2, 3`,
+		},
+		{
+			"missing",
+			"Development",
+			"",
+			false,
+			"",
+		},
+	}
+	for _, tc := range cases {
+		id, found, err := findAdoptedEntity("auto tag", tc.Input, entities)
+		if id != tc.ExpectedID || found != tc.ExpectedFound {
+			t.Fatalf("%s: unexpected ID.\nExpected: %s, %t\nGiven:    %s, %t", tc.Name, tc.ExpectedID, tc.ExpectedFound, id, found)
+		}
+		if (err == nil && tc.ExpectedError != "") || (err != nil && err.Error() != tc.ExpectedError) {
+			t.Fatalf("%s: unexpected error.\nExpected: %s\nGiven:    %v", tc.Name, tc.ExpectedError, err)
+		}
+	}
+}
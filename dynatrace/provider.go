@@ -2,8 +2,8 @@ package dynatrace
 
 import (
 	"context"
-	"fmt"
 	"net/url"
+	"sync"
 
 	dynatraceClusterV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/cluster/dynatrace"
 	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
@@ -11,6 +11,7 @@ import (
 	dynatraceEnvironmentV2 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/environment/dynatrace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider function for Dynatrace API
@@ -39,12 +40,26 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("DYNATRACE_API_TOKEN", nil),
 			},
+			"validate_on_plan": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"default_api": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("DYNATRACE_DEFAULT_API", "config"),
+				ValidateFunc: validation.StringInSlice([]string{"config", "settings"}, false),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"dynatrace_alerting_profile":           resourceDynatraceAlertingProfile(),
 			"dynatrace_management_zone":            resourceDynatraceManagementZone(),
 			"dynatrace_maintenance_window":         resourceDynatraceMaintenanceWindow(),
 			"dynatrace_dashboard":                  resourceDynatraceDashboard(),
+			"dynatrace_dashboard_json":             resourceDynatraceDashboardJSON(),
+			"dynatrace_dashboard_sharing":          resourceDynatraceDashboardSharing(),
+			"dynatrace_dashboard_report":           resourceDynatraceDashboardReport(),
 			"dynatrace_auto_tag":                   resourceDynatraceAutoTag(),
 			"dynatrace_notification":               resourceDynatraceNotification(),
 			"dynatrace_web_application":            resourceDynatraceWebApplication(),
@@ -53,11 +68,19 @@ func Provider() *schema.Provider {
 			"dynatrace_api_token":                  resourceDynatraceApiToken(),
 			"dynatrace_cluster_user":               resourceDynatraceClusterUser(),
 			"dynatrace_cluster_user_group":         resourceDynatraceClusterUserGroup(),
+			"dynatrace_settings_object":            resourceDynatraceSettingsObject(),
+			"dynatrace_config_json":                resourceDynatraceConfigJSON(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"dynatrace_alerting_profile": dataSourceDynatraceAlertingProfile(),
-			"dynatrace_management_zone":  dataSourceDynatraceManagementZone(),
-			"dynatrace_web_application":  dataSourceDynatraceWebApplication(),
+			"dynatrace_alerting_profile":    dataSourceDynatraceAlertingProfile(),
+			"dynatrace_management_zone":     dataSourceDynatraceManagementZone(),
+			"dynatrace_web_application":     dataSourceDynatraceWebApplication(),
+			"dynatrace_maintenance_window":  dataSourceDynatraceMaintenanceWindow(),
+			"dynatrace_maintenance_windows": dataSourceDynatraceMaintenanceWindows(),
+			"dynatrace_dashboard":           dataSourceDynatraceDashboard(),
+			"dynatrace_dashboards":          dataSourceDynatraceDashboards(),
+			"dynatrace_settings_objects":    dataSourceDynatraceSettingsObjects(),
+			"dynatrace_settings_schema":     dataSourceDynatraceSettingsSchema(),
 		},
 	}
 	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -77,6 +100,19 @@ type ProviderConfiguration struct {
 	AuthClusterV1                context.Context
 	AuthClusterV2                context.Context
 	AuthEnvironmentV2            context.Context
+	// Send planned configurations to the validator endpoints of the config API
+	ValidateOnPlan bool
+	// The API used by resources that can be managed through the config v1 or the
+	// Settings 2.0 API, unless they select one themselves
+	DefaultAPI string
+
+	// The base URLs of the config v1 and the environment v2 API, for restRequest
+	configV1URL      string
+	environmentV2URL string
+
+	// Settings 2.0 schemas fetched to validate settings objects, by ID and version
+	settingsSchemas     map[string]*settingsSchema
+	settingsSchemasLock sync.Mutex
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
@@ -84,6 +120,8 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 	dtClusterURL := d.Get("dt_cluster_url").(string)
 	apiToken := d.Get("dt_api_token").(string)
 	clusterApiToken := d.Get("dt_cluster_api_token").(string)
+	validateOnPlan := d.Get("validate_on_plan").(bool)
+	defaultAPI := d.Get("default_api").(string)
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -186,6 +224,25 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 	environmentV2 := dynatraceEnvironmentV2.NewConfiguration()
 	dynatraceEnvironmentClientV2 := dynatraceEnvironmentV2.NewAPIClient(environmentV2)
 
+	// Base URLs for the requests the generated clients can't send
+	configV1URL, err := configV1.ServerURLWithContext(authConfigV1, "")
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid Dynatrace Environment URL",
+			Detail:   err.Error(),
+		})
+	}
+
+	environmentV2URL, err := environmentV2.ServerURLWithContext(authEnvironmentV2, "")
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid Dynatrace Environment URL",
+			Detail:   err.Error(),
+		})
+	}
+
 	return &ProviderConfiguration{
 		DynatraceConfigClientV1:      dynatraceConfigClientV1,
 		DynatraceClusterClientV1:     dynatraceClusterClientV1,
@@ -195,32 +252,10 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 		AuthClusterV1:                authClusterV1,
 		AuthClusterV2:                authClusterV2,
 		AuthEnvironmentV2:            authEnvironmentV2,
+		ValidateOnPlan:               validateOnPlan,
+		DefaultAPI:                   defaultAPI,
+		configV1URL:                  configV1URL,
+		environmentV2URL:             environmentV2URL,
 	}, diags
 
 }
-
-func getErrorMessage(err error) string {
-	var errorMessage string
-
-	if apiErr, ok := err.(dynatraceConfigV1.GenericOpenAPIError); ok {
-		return fmt.Sprintf("%v: %s", err, apiErr.Body())
-	}
-
-	if apiErr, ok := err.(dynatraceClusterV1.GenericOpenAPIError); ok {
-		return fmt.Sprintf("%v: %s", err, apiErr.Body())
-	}
-
-	if apiErr, ok := err.(dynatraceClusterV2.GenericOpenAPIError); ok {
-		return fmt.Sprintf("%v: %s", err, apiErr.Body())
-	}
-
-	if apiErr, ok := err.(dynatraceEnvironmentV2.GenericOpenAPIError); ok {
-		return fmt.Sprintf("%v: %s", err, apiErr.Body())
-	}
-
-	if errURL, ok := err.(*url.Error); ok {
-		return fmt.Sprintf("%v:%s", err, errURL)
-	}
-
-	return errorMessage
-}
@@ -3,19 +3,20 @@ package dynatrace
 import (
 	"context"
 
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDynatraceAlertingProfile() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceAlertingProfileCreate,
 		ReadContext:   resourceDynatraceAlertingProfileRead,
 		UpdateContext: resourceDynatraceAlertingProfileUpdate,
 		DeleteContext: resourceDynatraceAlertingProfileDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: resourceDynatraceAlertingProfileCustomizeDiff,
+		Importer:      importStateByName("alerting profile", resourceDynatraceAlertingProfileNames),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"display_name": &schema.Schema{
@@ -23,6 +24,13 @@ func resourceDynatraceAlertingProfile() *schema.Resource {
 				Required:    true,
 				Description: "The name of the alerting profile, displayed in the UI.",
 			},
+			"adopt_existing": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Take over an existing alerting profile with the same display name instead of failing to create it. The alerting profile is updated to match the configuration.",
+				Optional:    true,
+				Default:     false,
+			},
+			"api": apiSchema(),
 			"mz_id": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -194,6 +202,31 @@ func resourceDynatraceAlertingProfile() *schema.Resource {
 			},
 		},
 	}
+
+	// IDs were config v1 IDs before version 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		settingsAPIStateUpgrader(0, r.Schema, alertingProfileSettings),
+	}
+
+	return r
+}
+
+// alertingProfileSettings manages alerting profiles through builtin:alerting.profile settings objects.
+var alertingProfileSettings = &settingsAPIResource{
+	description: "alerting profile",
+	schemaID:    "builtin:alerting.profile",
+	nameOf:      settingsValueName,
+	list:        resourceDynatraceAlertingProfileNames,
+}
+
+func resourceDynatraceAlertingProfileCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if plansSettingsAPI(d, m) {
+		return nil
+	}
+
+	return validateOnPlan(d, m, resourceDynatraceAlertingProfile(), "alerting profile", "/alertingProfiles", func(d *schema.ResourceData) (interface{}, error) {
+		return expandAlertingProfile(d)
+	})
 }
 
 func resourceDynatraceAlertingProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -205,28 +238,92 @@ func resourceDynatraceAlertingProfileCreate(ctx context.Context, d *schema.Resou
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
+	if usesSettingsAPI(d, providerConf) {
+		return resourceDynatraceAlertingProfileCreateSettings(ctx, d, m)
+	}
+
 	ap, err := expandAlertingProfile(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	alertingProfile, _, err := dynatraceConfigClientV1.AlertingProfilesApi.CreateAlertingProfile(authConfigV1).AlertingProfile(*ap).Execute()
+	alertingProfileID := ""
+
+	if d.Get("adopt_existing").(bool) {
+		existing, resp, err := dynatraceConfigClientV1.AlertingProfilesApi.GetAlertingProfiles(authConfigV1).Execute()
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to get dynatrace alerting profiles", err, resp, nil)...)
+			return diags
+		}
+
+		id, ok, err := findAdoptedEntity("alerting profile", ap.DisplayName, namedEntitiesFromStubs(existing.Values))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if ok {
+			alertingProfileID = id
+		}
+	}
+
+	if len(alertingProfileID) == 0 {
+		if alertingProfileID, err = newUUID(); err != nil {
+			return diag.FromErr(err)
+		}
+		// in the state before the request, an interrupted create leaves the alerting profile tainted
+		d.SetId(alertingProfileID)
+	}
+
+	_, resp, err := dynatraceConfigClientV1.AlertingProfilesApi.CreateOrUpdateAlertingProfile(authConfigV1, alertingProfileID).AlertingProfile(*ap).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create dynatrace alerting profile",
-			Detail:   getErrorMessage(err),
-		})
+		if createRejected(err, resp) {
+			d.SetId("")
+		}
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace alerting profile", err, resp, resourceDynatraceAlertingProfile().Schema)...)
 		return diags
 	}
 
-	d.SetId(alertingProfile.Id)
+	d.SetId(alertingProfileID)
 
 	resourceDynatraceAlertingProfileRead(ctx, d, m)
 
 	return diags
 }
 
+func resourceDynatraceAlertingProfileCreateSettings(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	value, err := expandAlertingProfileSettings(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("adopt_existing").(bool) {
+		objectID, ok, err := alertingProfileSettings.findSettingsObject(providerConf, settingsValueName(value))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if ok {
+			if diags := alertingProfileSettings.update(providerConf, objectID, value, resourceDynatraceAlertingProfile().Schema); diags.HasError() {
+				return diags
+			}
+
+			d.SetId(objectID)
+
+			return resourceDynatraceAlertingProfileRead(ctx, d, m)
+		}
+	}
+
+	objectID, diags := alertingProfileSettings.create(providerConf, value, resourceDynatraceAlertingProfile().Schema)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(objectID)
+
+	return resourceDynatraceAlertingProfileRead(ctx, d, m)
+}
+
 func resourceDynatraceAlertingProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
 	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
@@ -234,16 +331,33 @@ func resourceDynatraceAlertingProfileRead(ctx context.Context, d *schema.Resourc
 
 	var diags diag.Diagnostics
 
+	if err := alertingProfileSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace alerting profile", err, nil, nil)...)
+		return diags
+	}
+
 	alertingProfileID := d.Id()
 
-	alertingProfile, _, err := dynatraceConfigClientV1.AlertingProfilesApi.GetAlertingProfile(authConfigV1, alertingProfileID).Execute()
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read dynatrace alerting profile",
-			Detail:   getErrorMessage(err),
-		})
-		return diags
+	var alertingProfile *dynatraceConfigV1.AlertingProfile
+	if usesSettingsAPI(d, providerConf) {
+		value, diags := alertingProfileSettings.read(providerConf, alertingProfileID)
+		if diags.HasError() {
+			return diags
+		}
+
+		alertingProfile = flattenAlertingProfileSettings(value)
+	} else {
+		ap, resp, err := dynatraceConfigClientV1.AlertingProfilesApi.GetAlertingProfile(authConfigV1, alertingProfileID).Execute()
+		if entityNotFound(err, resp) {
+			d.SetId("")
+			return diags
+		}
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace alerting profile", err, resp, nil)...)
+			return diags
+		}
+
+		alertingProfile = &ap
 	}
 
 	alertingProfileRules := flattenAlertingProfileRulesData(alertingProfile.Rules)
@@ -269,8 +383,28 @@ func resourceDynatraceAlertingProfileUpdate(ctx context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
+	if err := alertingProfileSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace alerting profile", err, nil, nil)...)
+		return diags
+	}
+
 	alertingProfileID := d.Id()
 
+	if usesSettingsAPI(d, providerConf) {
+		if d.HasChange("display_name") || d.HasChange("mz_id") || d.HasChange("rule") || d.HasChange("event_type_filter") {
+			value, err := expandAlertingProfileSettings(d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if diags := alertingProfileSettings.update(providerConf, alertingProfileID, value, resourceDynatraceAlertingProfile().Schema); diags.HasError() {
+				return diags
+			}
+		}
+
+		return resourceDynatraceAlertingProfileRead(ctx, d, m)
+	}
+
 	if d.HasChange("display_name") || d.HasChange("rule") || d.HasChange("event_type_filter") {
 
 		ap, err := expandAlertingProfile(d)
@@ -278,13 +412,9 @@ func resourceDynatraceAlertingProfileUpdate(ctx context.Context, d *schema.Resou
 			return diag.FromErr(err)
 		}
 
-		_, _, err = dynatraceConfigClientV1.AlertingProfilesApi.CreateOrUpdateAlertingProfile(authConfigV1, alertingProfileID).AlertingProfile(*ap).Execute()
+		_, resp, err := dynatraceConfigClientV1.AlertingProfilesApi.CreateOrUpdateAlertingProfile(authConfigV1, alertingProfileID).AlertingProfile(*ap).Execute()
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update dynatrace alerting profile",
-				Detail:   getErrorMessage(err),
-			})
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace alerting profile", err, resp, resourceDynatraceAlertingProfile().Schema)...)
 			return diags
 		}
 	}
@@ -300,15 +430,26 @@ func resourceDynatraceAlertingProfileDelete(ctx context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
+	if err := alertingProfileSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace alerting profile", err, nil, nil)...)
+		return diags
+	}
+
 	alertingProfileID := d.Id()
 
-	_, err := dynatraceConfigClientV1.AlertingProfilesApi.DeleteAlertingProfile(authConfigV1, alertingProfileID).Execute()
+	if usesSettingsAPI(d, providerConf) {
+		if diags := alertingProfileSettings.delete(providerConf, alertingProfileID); diags.HasError() {
+			return diags
+		}
+
+		d.SetId("")
+
+		return diags
+	}
+
+	resp, err := dynatraceConfigClientV1.AlertingProfilesApi.DeleteAlertingProfile(authConfigV1, alertingProfileID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete dynatrace alerting profile",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace alerting profile", err, resp, nil)...)
 		return diags
 	}
 
@@ -317,3 +458,16 @@ func resourceDynatraceAlertingProfileDelete(ctx context.Context, d *schema.Resou
 	return diags
 
 }
+
+func resourceDynatraceAlertingProfileNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	stubs, _, err := dynatraceConfigClientV1.AlertingProfilesApi.GetAlertingProfiles(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return namedEntitiesFromStubs(stubs.Values), nil
+}
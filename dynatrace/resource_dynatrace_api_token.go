@@ -8,14 +8,13 @@ import (
 )
 
 func resourceDynatraceApiToken() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceApiTokenCreate,
 		ReadContext:   resourceDynatraceApiTokenRead,
 		UpdateContext: resourceDynatraceApiTokenUpdate,
 		DeleteContext: resourceDynatraceApiTokenDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer:      importStateByName("api token", resourceDynatraceApiTokenNames),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -41,7 +40,7 @@ func resourceDynatraceApiToken() *schema.Resource {
 				Optional:    true,
 			},
 			"scopes": &schema.Schema{
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Description: "A list of the scopes to be assigned to the token.",
 				Optional:    true,
 				Elem: &schema.Schema{
@@ -80,6 +79,13 @@ func resourceDynatraceApiToken() *schema.Resource {
 			},
 		},
 	}
+
+	// scopes were a list before version 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		listsToSetsStateUpgrader(0, r.Schema, "scopes"),
+	}
+
+	return r
 }
 
 func resourceDynatraceApiTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -95,13 +101,9 @@ func resourceDynatraceApiTokenCreate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	apiToken, _, err := dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.CreateApiToken(authEnvironmentV2).ApiTokenCreate(*at).Execute()
+	apiToken, resp, err := dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.CreateApiToken(authEnvironmentV2).ApiTokenCreate(*at).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create api token",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create api token", err, resp, resourceDynatraceApiToken().Schema)...)
 		return diags
 	}
 
@@ -124,13 +126,9 @@ func resourceDynatraceApiTokenRead(ctx context.Context, d *schema.ResourceData,
 
 	apiTokenID := d.Id()
 
-	apiToken, _, err := dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.GetApiToken(authEnvironmentV2, apiTokenID).Execute()
+	apiToken, resp, err := dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.GetApiToken(authEnvironmentV2, apiTokenID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read api token",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read api token", err, resp, nil)...)
 		return diags
 	}
 
@@ -155,13 +153,9 @@ func resourceDynatraceApiTokenUpdate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	_, err = dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.UpdateApiToken(authEnvironmentV2, apiTokenID).ApiTokenUpdate(*at).Execute()
+	resp, err := dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.UpdateApiToken(authEnvironmentV2, apiTokenID).ApiTokenUpdate(*at).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update api token",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to update api token", err, resp, resourceDynatraceApiToken().Schema)...)
 		return diags
 	}
 
@@ -179,13 +173,9 @@ func resourceDynatraceApiTokenDelete(ctx context.Context, d *schema.ResourceData
 
 	apiTokenID := d.Id()
 
-	_, err := dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.DeleteApiToken(authEnvironmentV2, apiTokenID).Execute()
+	resp, err := dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.DeleteApiToken(authEnvironmentV2, apiTokenID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete api token",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete api token", err, resp, nil)...)
 		return diags
 	}
 
@@ -193,3 +183,35 @@ func resourceDynatraceApiTokenDelete(ctx context.Context, d *schema.ResourceData
 
 	return diags
 }
+
+func resourceDynatraceApiTokenNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceEnvironmentClientV2 := providerConf.DynatraceEnvironmentClientV2
+	authEnvironmentV2 := providerConf.AuthEnvironmentV2
+
+	entities := []namedEntity{}
+	err := listPages(func(nextPageKey string) (*string, error) {
+		request := dynatraceEnvironmentClientV2.AccessTokensAPITokensApi.ListApiTokens(authEnvironmentV2)
+		if len(nextPageKey) != 0 {
+			request = request.NextPageKey(nextPageKey)
+		}
+
+		apiTokens, _, err := request.Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, apiToken := range apiTokens.GetApiTokens() {
+			if apiToken.Id != nil && apiToken.Name != nil {
+				entities = append(entities, namedEntity{id: *apiToken.Id, name: *apiToken.Name})
+			}
+		}
+
+		return apiTokens.NextPageKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
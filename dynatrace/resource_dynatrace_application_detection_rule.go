@@ -13,9 +13,7 @@ func resourceDynatraceApplicationDetectionRule() *schema.Resource {
 		ReadContext:   resourceDynatraceApplicationDetectionRuleRead,
 		UpdateContext: resourceDynatraceApplicationDetectionRuleUpdate,
 		DeleteContext: resourceDynatraceApplicationDetectionRuleDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer:      importStateByName("application detection rule", resourceDynatraceApplicationDetectionRuleNames),
 		Schema: map[string]*schema.Schema{
 			"order": &schema.Schema{
 				Type:        schema.TypeString,
@@ -73,13 +71,9 @@ func resourceDynatraceApplicationDetectionRuleCreate(ctx context.Context, d *sch
 		return diag.FromErr(err)
 	}
 
-	applicationDetectionRule, _, err := dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.CreateApplicationDetectionConfig(authConfigV1).ApplicationDetectionRuleConfig(*dr).Execute()
+	applicationDetectionRule, resp, err := dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.CreateApplicationDetectionConfig(authConfigV1).ApplicationDetectionRuleConfig(*dr).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create dynatrace application detection rule",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace application detection rule", err, resp, resourceDynatraceApplicationDetectionRule().Schema)...)
 		return diags
 	}
 
@@ -99,13 +93,9 @@ func resourceDynatraceApplicationDetectionRuleRead(ctx context.Context, d *schem
 
 	applicationDetectionRuleID := d.Id()
 
-	applicationDetectionRule, _, err := dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.GetApplicationDetectionConfig(authConfigV1, applicationDetectionRuleID).Execute()
+	applicationDetectionRule, resp, err := dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.GetApplicationDetectionConfig(authConfigV1, applicationDetectionRuleID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read dynatrace application detection rule",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace application detection rule", err, resp, nil)...)
 		return diags
 	}
 
@@ -129,13 +119,9 @@ func resourceDynatraceApplicationDetectionRuleUpdate(ctx context.Context, d *sch
 		return diag.FromErr(err)
 	}
 
-	_, _, err = dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.UpdateApplicationDetectionConfig(authConfigV1, applicationDetectionRuleID).ApplicationDetectionRuleConfig(*dr).Execute()
+	_, resp, err := dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.UpdateApplicationDetectionConfig(authConfigV1, applicationDetectionRuleID).ApplicationDetectionRuleConfig(*dr).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update dynatrace application detection rule",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace application detection rule", err, resp, resourceDynatraceApplicationDetectionRule().Schema)...)
 		return diags
 	}
 
@@ -152,13 +138,9 @@ func resourceDynatraceApplicationDetectionRuleDelete(ctx context.Context, d *sch
 
 	applicationDetectionRuleID := d.Id()
 
-	_, err := dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.DeleteApplicationDetectionConfig(authConfigV1, applicationDetectionRuleID).Execute()
+	resp, err := dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.DeleteApplicationDetectionConfig(authConfigV1, applicationDetectionRuleID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete dynatrace application detection rule",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace application detection rule", err, resp, nil)...)
 		return diags
 	}
 
@@ -166,3 +148,16 @@ func resourceDynatraceApplicationDetectionRuleDelete(ctx context.Context, d *sch
 
 	return diags
 }
+
+func resourceDynatraceApplicationDetectionRuleNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	stubs, _, err := dynatraceConfigClientV1.RUMApplicationDetectionRulesApi.ListApplicationDetectionConfigs(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return namedEntitiesFromStubs(stubs.Values), nil
+}
@@ -2,20 +2,23 @@ package dynatrace
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDynatraceAutoTag() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceAutoTagCreate,
 		ReadContext:   resourceDynatraceAutoTagRead,
 		UpdateContext: resourceDynatraceAutoTagUpdate,
 		DeleteContext: resourceDynatraceAutoTagDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: resourceDynatraceAutoTagCustomizeDiff,
+		Importer:      importStateByName("auto tag", resourceDynatraceAutoTagNames),
+		SchemaVersion: 2,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -23,6 +26,13 @@ func resourceDynatraceAutoTag() *schema.Resource {
 				Description: "The name of the auto-tag, which is applied to entities. Additionally you can specify a valueFormat in the tag rule. In that case the tag is used in the name:valueFormat format. For example you can extend the Infrastructure tag to Infrastructure:Windows and Infrastructure:Linux.",
 				Required:    true,
 			},
+			"adopt_existing": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Take over an existing auto tag with the same name instead of failing to create it. The auto tag is updated to match the configuration.",
+				Optional:    true,
+				Default:     false,
+			},
+			"api": apiSchema(),
 			"rule": &schema.Schema{
 				Type:        schema.TypeList,
 				Description: "The list of rules for tag usage. When there are multiple rules, the OR logic applies.",
@@ -45,7 +55,7 @@ func resourceDynatraceAutoTag() *schema.Resource {
 							Optional:    true,
 						},
 						"propagation_types": &schema.Schema{
-							Type:        schema.TypeList,
+							Type:        schema.TypeSet,
 							Description: "How to apply the tag to underlying entities.",
 							Optional:    true,
 							Elem: &schema.Schema{
@@ -61,8 +71,85 @@ func resourceDynatraceAutoTag() *schema.Resource {
 					},
 				},
 			},
+			"entity_selector_rule": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "A list of rules applying the tag to the entities matched by an entity selector, for example type(SERVICE),tag(\"Infrastructure:Linux\"). Requires the Settings 2.0 API.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Description: "Tag rule is enabled (true) or disabled (false).",
+							Optional:    true,
+							Default:     true,
+						},
+						"selector": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The entity selector matching the entities the tag is applied to.",
+							Required:    true,
+						},
+						"value_format": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The value of the auto-tag. If specified, the tag is used in the name:valueFormat format.",
+							Optional:    true,
+						},
+						"value_normalization": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "How the value of the tag is normalized: Leave text as-is, To lower case or To upper case.",
+							Optional:     true,
+							Default:      autoTagValueNormalizations[0],
+							ValidateFunc: validation.StringInSlice(autoTagValueNormalizations, false),
+						},
+					},
+				},
+			},
 		},
 	}
+
+	// propagation types were a list before version 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		listsToSetsStateUpgrader(0, r.Schema, "rule.propagation_types"),
+		settingsAPIStateUpgrader(1, r.Schema, autoTagSettings),
+	}
+
+	return r
+}
+
+// autoTagSettings manages auto tags through builtin:tags.auto-tagging settings objects.
+var autoTagSettings = &settingsAPIResource{
+	description: "auto tag",
+	schemaID:    "builtin:tags.auto-tagging",
+	nameOf:      settingsValueName,
+	list:        resourceDynatraceAutoTagNames,
+}
+
+func resourceDynatraceAutoTagCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// rules interpolated from other resources are only checked once they are known
+	if !d.NewValueKnown("rule") {
+		return nil
+	}
+
+	errs := validateRuleConditions(d)
+	if len(errs) != 0 {
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = "  - " + err.Error()
+		}
+
+		return fmt.Errorf("invalid auto tag rules:\n%s", strings.Join(messages, "\n"))
+	}
+
+	if plansSettingsAPI(d, m) {
+		return nil
+	}
+
+	if rules, ok := d.Get("entity_selector_rule").([]interface{}); ok && len(rules) != 0 {
+		return fmt.Errorf("entity_selector_rule: requires the Settings 2.0 API, set api to settings")
+	}
+
+	return validateOnPlan(d, m, resourceDynatraceAutoTag(), "auto tag", "/autoTags", func(d *schema.ResourceData) (interface{}, error) {
+		return expandAutoTag(d)
+	})
 }
 
 func resourceDynatraceAutoTagCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -73,22 +160,51 @@ func resourceDynatraceAutoTagCreate(ctx context.Context, d *schema.ResourceData,
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
+	if usesSettingsAPI(d, providerConf) {
+		return resourceDynatraceAutoTagCreateSettings(ctx, d, m)
+	}
+
 	at, err := expandAutoTag(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	autoTag, _, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.CreateAutoTag(authConfigV1).AutoTag(*at).Execute()
+	autoTagID := ""
+
+	if d.Get("adopt_existing").(bool) {
+		existing, resp, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.ListAutoTags(authConfigV1).Execute()
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to get auto tags", err, resp, nil)...)
+			return diags
+		}
+
+		id, ok, err := findAdoptedEntity("auto tag", at.Name, namedEntitiesFromStubs(existing.Values))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if ok {
+			autoTagID = id
+		}
+	}
+
+	if len(autoTagID) == 0 {
+		if autoTagID, err = newUUID(); err != nil {
+			return diag.FromErr(err)
+		}
+		// in the state before the request, an interrupted create leaves the auto tag tainted
+		d.SetId(autoTagID)
+	}
+
+	_, resp, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.UpdateAutoTag(authConfigV1, autoTagID).AutoTag(*at).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create auto tag",
-			Detail:   getErrorMessage(err),
-		})
+		if createRejected(err, resp) {
+			d.SetId("")
+		}
+		diags = append(diags, apiErrorDiagnostics("Unable to create auto tag", err, resp, resourceDynatraceAutoTag().Schema)...)
 		return diags
 	}
 
-	d.SetId(autoTag.Id)
+	d.SetId(autoTagID)
 
 	resourceDynatraceAutoTagRead(ctx, d, m)
 
@@ -96,6 +212,41 @@ func resourceDynatraceAutoTagCreate(ctx context.Context, d *schema.ResourceData,
 
 }
 
+func resourceDynatraceAutoTagCreateSettings(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	value, err := expandAutoTagSettings(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("adopt_existing").(bool) {
+		objectID, ok, err := autoTagSettings.findSettingsObject(providerConf, settingsValueName(value))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if ok {
+			if diags := autoTagSettings.update(providerConf, objectID, value, resourceDynatraceAutoTag().Schema); diags.HasError() {
+				return diags
+			}
+
+			d.SetId(objectID)
+
+			return resourceDynatraceAutoTagRead(ctx, d, m)
+		}
+	}
+
+	objectID, diags := autoTagSettings.create(providerConf, value, resourceDynatraceAutoTag().Schema)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(objectID)
+
+	return resourceDynatraceAutoTagRead(ctx, d, m)
+}
+
 func resourceDynatraceAutoTagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
 	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
@@ -103,19 +254,29 @@ func resourceDynatraceAutoTagRead(ctx context.Context, d *schema.ResourceData, m
 
 	var diags diag.Diagnostics
 
+	if err := autoTagSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace auto tag", err, nil, nil)...)
+		return diags
+	}
+
+	if usesSettingsAPI(d, providerConf) {
+		return resourceDynatraceAutoTagReadSettings(ctx, d, m)
+	}
+
 	autoTagID := d.Id()
 
-	autoTag, _, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.GetAutoTag(authConfigV1, autoTagID).Execute()
+	autoTag, resp, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.GetAutoTag(authConfigV1, autoTagID).Execute()
+	if entityNotFound(err, resp) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read dynatrace auto tag",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace auto tag", err, resp, nil)...)
 		return diags
 	}
 
 	autoTagRules := flattenAutoTagRulesData(autoTag.Rules)
+	selectConditionValueForms(d, autoTagRules)
 	if err := d.Set("rule", autoTagRules); err != nil {
 		return diag.FromErr(err)
 	}
@@ -126,6 +287,34 @@ func resourceDynatraceAutoTagRead(ctx context.Context, d *schema.ResourceData, m
 
 }
 
+func resourceDynatraceAutoTagReadSettings(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	value, diags := autoTagSettings.read(providerConf, d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	autoTag, selectorRules, err := flattenAutoTagSettings(value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	autoTagRules := flattenAutoTagRulesData(autoTag.Rules)
+	selectConditionValueForms(d, autoTagRules)
+	if err := d.Set("rule", autoTagRules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("entity_selector_rule", selectorRules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", autoTag.Name)
+
+	return diags
+}
+
 func resourceDynatraceAutoTagUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
 	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
@@ -133,8 +322,28 @@ func resourceDynatraceAutoTagUpdate(ctx context.Context, d *schema.ResourceData,
 
 	var diags diag.Diagnostics
 
+	if err := autoTagSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace auto tag", err, nil, nil)...)
+		return diags
+	}
+
 	autoTagID := d.Id()
 
+	if usesSettingsAPI(d, providerConf) {
+		if d.HasChange("name") || d.HasChange("rule") || d.HasChange("entity_selector_rule") {
+			value, err := expandAutoTagSettings(d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if diags := autoTagSettings.update(providerConf, autoTagID, value, resourceDynatraceAutoTag().Schema); diags.HasError() {
+				return diags
+			}
+		}
+
+		return resourceDynatraceAutoTagRead(ctx, d, m)
+	}
+
 	if d.HasChange("name") || d.HasChange("rule") {
 
 		at, err := expandAutoTag(d)
@@ -142,13 +351,9 @@ func resourceDynatraceAutoTagUpdate(ctx context.Context, d *schema.ResourceData,
 			return diag.FromErr(err)
 		}
 
-		_, _, err = dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.UpdateAutoTag(authConfigV1, autoTagID).AutoTag(*at).Execute()
+		_, resp, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.UpdateAutoTag(authConfigV1, autoTagID).AutoTag(*at).Execute()
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update dynatrace auto tag",
-				Detail:   getErrorMessage(err),
-			})
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace auto tag", err, resp, resourceDynatraceAutoTag().Schema)...)
 			return diags
 		}
 
@@ -165,15 +370,26 @@ func resourceDynatraceAutoTagDelete(ctx context.Context, d *schema.ResourceData,
 
 	var diags diag.Diagnostics
 
+	if err := autoTagSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete auto tag", err, nil, nil)...)
+		return diags
+	}
+
 	autoTagID := d.Id()
 
-	_, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.DeleteAutoTag(authConfigV1, autoTagID).Execute()
+	if usesSettingsAPI(d, providerConf) {
+		if diags := autoTagSettings.delete(providerConf, autoTagID); diags.HasError() {
+			return diags
+		}
+
+		d.SetId("")
+
+		return diags
+	}
+
+	resp, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.DeleteAutoTag(authConfigV1, autoTagID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete auto tag",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete auto tag", err, resp, nil)...)
 		return diags
 	}
 
@@ -181,3 +397,16 @@ func resourceDynatraceAutoTagDelete(ctx context.Context, d *schema.ResourceData,
 
 	return diags
 }
+
+func resourceDynatraceAutoTagNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	stubs, _, err := dynatraceConfigClientV1.AutomaticallyAppliedTagsApi.ListAutoTags(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return namedEntitiesFromStubs(stubs.Values), nil
+}
@@ -9,14 +9,13 @@ import (
 )
 
 func resourceDynatraceClusterUserGroup() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceClusterUserGroupCreate,
 		ReadContext:   resourceDynatraceClusterUserGroupRead,
 		UpdateContext: resourceDynatraceClusterUserGroupUpdate,
 		DeleteContext: resourceDynatraceClusterUserGroupDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer:      importStateByName("cluster user group", resourceDynatraceClusterUserGroupNames),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -54,7 +53,7 @@ func resourceDynatraceClusterUserGroup() *schema.Resource {
 				Default:     false,
 			},
 			"ldap_group_names": &schema.Schema{
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Description: "LDAP group names",
 				Optional:    true,
 				Elem: &schema.Schema{
@@ -62,7 +61,7 @@ func resourceDynatraceClusterUserGroup() *schema.Resource {
 				},
 			},
 			"sso_group_names": &schema.Schema{
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Description: "SSO group names. If defined it's used to map SSO group name to Dynatrace group name, otherwise mapping is done by group name",
 				Optional:    true,
 				Elem: &schema.Schema{
@@ -77,6 +76,13 @@ func resourceDynatraceClusterUserGroup() *schema.Resource {
 			},
 		},
 	}
+
+	// group names were lists before version 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		listsToSetsStateUpgrader(0, r.Schema, "ldap_group_names", "sso_group_names"),
+	}
+
+	return r
 }
 
 func resourceDynatraceClusterUserGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -92,13 +98,9 @@ func resourceDynatraceClusterUserGroupCreate(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	clusterUserGroup, _, err := dynatraceClusterV1.UserGroupsApi.CreateGroup(authClusterV1).GroupConfig(*cu).Execute()
+	clusterUserGroup, resp, err := dynatraceClusterV1.UserGroupsApi.CreateGroup(authClusterV1).GroupConfig(*cu).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create cluster user group",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create cluster user group", err, resp, resourceDynatraceClusterUserGroup().Schema)...)
 		return diags
 	}
 
@@ -120,13 +122,9 @@ func resourceDynatraceClusterUserGroupRead(ctx context.Context, d *schema.Resour
 
 	clusterUserGroupID := d.Id()
 
-	clusterUserGroup, _, err := dynatraceClusterV1.UserGroupsApi.GetGroup(authClusterV1, clusterUserGroupID).Execute()
+	clusterUserGroup, resp, err := dynatraceClusterV1.UserGroupsApi.GetGroup(authClusterV1, clusterUserGroupID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read cluster user group",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read cluster user group", err, resp, nil)...)
 		return diags
 	}
 
@@ -149,13 +147,9 @@ func resourceDynatraceClusterUserGroupUpdate(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	_, _, err = dynatraceClusterV1.UserGroupsApi.UpdateGroup(authClusterV1).GroupConfig(*cu).Execute()
+	_, resp, err := dynatraceClusterV1.UserGroupsApi.UpdateGroup(authClusterV1).GroupConfig(*cu).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update cluster user group",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to update cluster user group", err, resp, resourceDynatraceClusterUserGroup().Schema)...)
 		return diags
 	}
 
@@ -173,13 +167,9 @@ func resourceDynatraceClusterUserGroupDelete(ctx context.Context, d *schema.Reso
 
 	clusterUserGroupID := d.Id()
 
-	_, _, err := dynatraceClusterV1.UserGroupsApi.RemoveGroup(authClusterV1, clusterUserGroupID).Execute()
+	_, resp, err := dynatraceClusterV1.UserGroupsApi.RemoveGroup(authClusterV1, clusterUserGroupID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete cluster user group",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete cluster user group", err, resp, nil)...)
 		return diags
 	}
 
@@ -188,3 +178,21 @@ func resourceDynatraceClusterUserGroupDelete(ctx context.Context, d *schema.Reso
 	return diags
 
 }
+
+func resourceDynatraceClusterUserGroupNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceClusterV1 := providerConf.DynatraceClusterClientV1
+	authClusterV1 := providerConf.AuthClusterV1
+
+	groups, _, err := dynatraceClusterV1.UserGroupsApi.GetGroups(authClusterV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]namedEntity, 0, len(groups))
+	for _, group := range groups {
+		entities = append(entities, namedEntity{id: group.Id, name: group.Name})
+	}
+
+	return entities, nil
+}
@@ -13,9 +13,7 @@ func resourceDynatraceClusterUser() *schema.Resource {
 		ReadContext:   resourceDynatraceClusterUserRead,
 		UpdateContext: resourceDynatraceClusterUserUpdate,
 		DeleteContext: resourceDynatraceClusterUserDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer:      importStateByName("cluster user", resourceDynatraceClusterUserNames),
 
 		Schema: map[string]*schema.Schema{
 			"user_id": &schema.Schema{
@@ -68,13 +66,9 @@ func resourceDynatraceClusterUserCreate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	clusterUser, _, err := dynatraceClusterV1.UsersApi.CreateUser(authClusterV1).UserConfig(*cu).Execute()
+	clusterUser, resp, err := dynatraceClusterV1.UsersApi.CreateUser(authClusterV1).UserConfig(*cu).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create cluster user",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create cluster user", err, resp, resourceDynatraceClusterUser().Schema)...)
 		return diags
 	}
 
@@ -96,13 +90,9 @@ func resourceDynatraceClusterUserRead(ctx context.Context, d *schema.ResourceDat
 
 	clusterUserID := d.Id()
 
-	clusterUser, _, err := dynatraceClusterV1.UsersApi.GetUser(authClusterV1, clusterUserID).Execute()
+	clusterUser, resp, err := dynatraceClusterV1.UsersApi.GetUser(authClusterV1, clusterUserID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read cluster user",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read cluster user", err, resp, nil)...)
 		return diags
 	}
 
@@ -125,13 +115,9 @@ func resourceDynatraceClusterUserUpdate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	_, _, err = dynatraceClusterV1.UsersApi.UpdateUser(authClusterV1).UserConfig(*cu).Execute()
+	_, resp, err := dynatraceClusterV1.UsersApi.UpdateUser(authClusterV1).UserConfig(*cu).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update cluster user",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to update cluster user", err, resp, resourceDynatraceClusterUser().Schema)...)
 		return diags
 	}
 
@@ -149,13 +135,9 @@ func resourceDynatraceClusterUserDelete(ctx context.Context, d *schema.ResourceD
 
 	clusterUserID := d.Id()
 
-	_, _, err := dynatraceClusterV1.UsersApi.RemoveUser(authClusterV1, clusterUserID).Execute()
+	_, resp, err := dynatraceClusterV1.UsersApi.RemoveUser(authClusterV1, clusterUserID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete cluster user",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete cluster user", err, resp, nil)...)
 		return diags
 	}
 
@@ -164,3 +146,22 @@ func resourceDynatraceClusterUserDelete(ctx context.Context, d *schema.ResourceD
 	return diags
 
 }
+
+// resourceDynatraceClusterUserNames lists users under their full names.
+func resourceDynatraceClusterUserNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceClusterV1 := providerConf.DynatraceClusterClientV1
+	authClusterV1 := providerConf.AuthClusterV1
+
+	users, _, err := dynatraceClusterV1.UsersApi.GetUsers(authClusterV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]namedEntity, 0, len(users))
+	for _, user := range users {
+		entities = append(entities, namedEntity{id: user.Id, name: user.FirstName + " " + user.LastName})
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,235 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDynatraceConfigJSON() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDynatraceConfigJSONCreate,
+		ReadContext:   resourceDynatraceConfigJSONRead,
+		UpdateContext: resourceDynatraceConfigJSONUpdate,
+		DeleteContext: resourceDynatraceConfigJSONDelete,
+		CustomizeDiff: resourceDynatraceConfigJSONCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDynatraceConfigJSONImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"path": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The path of the endpoint relative to the config v1 API, for example /anomalyDetection/applications or /alertingProfiles.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/[^?#]+$`), "must start with / and hold neither a query nor a fragment"),
+			},
+			"style": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "How the endpoint manages its configuration: collection for endpoints holding entities that are created with POST and addressed by ID, singleton for endpoints holding a single configuration that is replaced with PUT.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "collection",
+				ValidateFunc: validation.StringInSlice([]string{"collection", "singleton"}, false),
+			},
+			"body": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The configuration as JSON. The metadata field, the id of entities of collections and the ignore_paths are left out when comparing it to the configuration read from the API.",
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				StateFunc:    normalizeJSONState,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					ignoredPaths := configJSONIgnoredPaths(d.Get("style").(string), d.Get("ignore_paths").([]interface{}))
+
+					oldContents, err := normalizeConfigJSON(old, ignoredPaths)
+					if err != nil {
+						return false
+					}
+					newContents, err := normalizeConfigJSON(new, ignoredPaths)
+					if err != nil {
+						return false
+					}
+					return oldContents == newContents
+				},
+			},
+			"ignore_paths": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Paths of fields assigned by the server, which are left out when comparing the configuration. The keys of a path are separated by dots, a * matches all elements of an array or all keys of an object, for example rules.*.id.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"on_delete": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "What happens to the configuration when the resource is destroyed: delete sends a DELETE request, reset replaces the configuration with reset_body and keep leaves it as it is. Defaults to delete for collections and keep for singletons.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"delete", "reset", "keep"}, false),
+			},
+			"reset_body": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The configuration as JSON that replaces the configuration when the resource is destroyed with on_delete set to reset.",
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				StateFunc:    normalizeJSONState,
+			},
+		},
+	}
+}
+
+func resourceDynatraceConfigJSONCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("on_delete").(string) == "reset" && d.NewValueKnown("reset_body") && len(d.Get("reset_body").(string)) == 0 {
+		return fmt.Errorf("reset_body: is required when on_delete is reset")
+	}
+
+	return nil
+}
+
+// resourceDynatraceConfigJSONImport takes the path of a singleton, or the path of
+// a collection and the ID of one of its entities separated by a comma.
+func resourceDynatraceConfigJSONImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ",", 2)
+
+	if !strings.HasPrefix(parts[0], "/") || (len(parts) == 2 && len(parts[1]) == 0) {
+		return nil, fmt.Errorf("expected the path of a singleton like /anomalyDetection/applications or <collection path>,<id> like /alertingProfiles,c01a2b3c-..., got %q", d.Id())
+	}
+
+	d.Set("path", parts[0])
+
+	if len(parts) == 2 {
+		d.Set("style", "collection")
+		d.SetId(parts[1])
+	} else {
+		d.Set("style", "singleton")
+		d.SetId(parts[0])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceDynatraceConfigJSONCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	path := d.Get("path").(string)
+
+	body, err := expandConfigJSON(d.Get("body").(string), "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("style").(string) == "singleton" {
+		if _, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, path, body); err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace configuration", err, nil, nil)...)
+			return diags
+		}
+
+		d.SetId(path)
+
+		return resourceDynatraceConfigJSONRead(ctx, d, m)
+	}
+
+	response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPost, path, body)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace configuration", err, nil, nil)...)
+		return diags
+	}
+
+	var entity dynatraceConfigV1.EntityShortRepresentation
+	if err := json.Unmarshal(response, &entity); err != nil || len(entity.Id) == 0 {
+		return diag.FromErr(fmt.Errorf("the response to creating an entity of %s holds no ID", path))
+	}
+
+	d.SetId(entity.Id)
+
+	return resourceDynatraceConfigJSONRead(ctx, d, m)
+}
+
+func resourceDynatraceConfigJSONRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	var diags diag.Diagnostics
+
+	response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, configJSONEntityPath(d), nil)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace configuration", err, nil, nil)...)
+		return diags
+	}
+
+	ignoredPaths := configJSONIgnoredPaths(d.Get("style").(string), d.Get("ignore_paths").([]interface{}))
+
+	contents, err := normalizeConfigJSON(string(response), ignoredPaths)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("body", contents); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDynatraceConfigJSONUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	var diags diag.Diagnostics
+
+	if d.HasChange("body") {
+		id := ""
+		if d.Get("style").(string) != "singleton" {
+			id = d.Id()
+		}
+
+		body, err := expandConfigJSON(d.Get("body").(string), id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if _, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, configJSONEntityPath(d), body); err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace configuration", err, nil, nil)...)
+			return diags
+		}
+	}
+
+	return resourceDynatraceConfigJSONRead(ctx, d, m)
+}
+
+func resourceDynatraceConfigJSONDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	var diags diag.Diagnostics
+
+	switch configJSONOnDelete(d) {
+	case "delete":
+		if _, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodDelete, configJSONEntityPath(d), nil); err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace configuration", err, nil, nil)...)
+			return diags
+		}
+	case "reset":
+		body, err := expandConfigJSON(d.Get("reset_body").(string), "")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if _, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, configJSONEntityPath(d), body); err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to reset dynatrace configuration", err, nil, nil)...)
+			return diags
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,111 @@
+package dynatrace
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDynatraceConfigJSON_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "dynatrace_config_json.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDynatraceConfigJSONDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceConfigJSONConfig(name, "ERROR"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceConfigJSONExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "path", "/alertingProfiles"),
+					resource.TestCheckResourceAttr(resourceName, "style", "collection"),
+				),
+			},
+			{
+				Config: testAccDynatraceConfigJSONConfig(name, "AVAILABILITY"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceConfigJSONExists(resourceName),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return "/alertingProfiles," + s.RootModule().Resources[resourceName].Primary.ID, nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ignore_paths"},
+			},
+		},
+	})
+}
+
+func testAccCheckDynatraceConfigJSONDestroy(s *terraform.State) error {
+	providerConf := testAccProvider.Meta().(*ProviderConfiguration)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dynatrace_config_json" {
+			continue
+		}
+
+		path := rs.Primary.Attributes["path"] + "/" + rs.Primary.ID
+
+		if _, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, path, nil); err == nil {
+			return fmt.Errorf("Configuration still exists: %s", path)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynatraceConfigJSONExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		providerConf := testAccProvider.Meta().(*ProviderConfiguration)
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		path := rs.Primary.Attributes["path"] + "/" + rs.Primary.ID
+
+		if _, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, path, nil); err != nil {
+			return fmt.Errorf("Configuration does not exist: %s", path)
+		}
+
+		return nil
+	}
+}
+
+func testAccDynatraceConfigJSONConfig(name string, severityLevel string) string {
+	return fmt.Sprintf(`resource "dynatrace_config_json" "test" {
+		path = "/alertingProfiles"
+		body = jsonencode({
+		  displayName = "%s"
+		  rules = [
+			{
+			  severityLevel  = "%s"
+			  delayInMinutes = 0
+			  tagFilter = {
+				includeMode = "NONE"
+				tagFilters  = []
+			  }
+			}
+		  ]
+		  eventTypeFilters = []
+		})
+		ignore_paths = ["managementZoneId"]
+	  }
+`, name, severityLevel)
+}
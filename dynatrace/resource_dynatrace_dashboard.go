@@ -2,24 +2,66 @@ package dynatrace
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"strings"
 
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDynatraceDashboard() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceDashboardCreate,
 		ReadContext:   resourceDynatraceDashboardRead,
 		UpdateContext: resourceDynatraceDashboardUpdate,
 		DeleteContext: resourceDynatraceDashboardDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: resourceDynatraceDashboardCustomizeDiff,
+		Importer:      importStateByName("dashboard", resourceDynatraceDashboardNames),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
+			"layout": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The grid used to compute the bounds of the tiles with a layout block. Rows are placed one below the other, and the tiles of a row left to right in the order they are declared.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"top": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							Description:  "The vertical distance from the top of the dashboard to the first row, in pixels.",
+							ValidateFunc: validateDashboardGridMultiple,
+						},
+						"left": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							Description:  "The horizontal distance from the left of the dashboard to the first column, in pixels.",
+							ValidateFunc: validateDashboardGridMultiple,
+						},
+						"column_width": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      152,
+							Description:  "The width of a column, in pixels. Must be a multiple of 38.",
+							ValidateFunc: validation.All(validation.IntAtLeast(38), validateDashboardGridMultiple),
+						},
+						"row_height": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      152,
+							Description:  "The height of a row, in pixels. Must be a multiple of 38.",
+							ValidateFunc: validation.All(validation.IntAtLeast(38), validateDashboardGridMultiple),
+						},
+					},
+				},
+			},
 			"dashboard_metadata": &schema.Schema{
 				Type:        schema.TypeList,
 				Description: "Parameters of a dashboard.",
@@ -46,7 +88,7 @@ func resourceDynatraceDashboard() *schema.Resource {
 							},
 						},
 						"tags": &schema.Schema{
-							Type:        schema.TypeList,
+							Type:        schema.TypeSet,
 							Description: "A set of tags assigned to the dashboard.",
 							Optional:    true,
 							Elem: &schema.Schema{
@@ -150,8 +192,9 @@ func resourceDynatraceDashboard() *schema.Resource {
 						},
 						"bounds": &schema.Schema{
 							Type:        schema.TypeList,
-							Description: "The position and size of a tile.",
-							Required:    true,
+							Description: "The position and size of a tile. Required unless the tile has a layout block, the bounds of which are computed from the dashboard layout.",
+							Optional:    true,
+							MaxItems:    1,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"top": &schema.Schema{
@@ -177,6 +220,36 @@ func resourceDynatraceDashboard() *schema.Resource {
 								},
 							},
 						},
+						"layout": &schema.Schema{
+							Type:        schema.TypeList,
+							Description: "The position of the tile in the grid of the dashboard layout block, used instead of bounds. The computed bounds are sent to the API but not kept in the state.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"row": &schema.Schema{
+										Type:         schema.TypeInt,
+										Required:     true,
+										Description:  "The row of the tile. Rows are ordered by number, gaps between numbers are ignored.",
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"column_span": &schema.Schema{
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1,
+										Description:  "The number of columns the tile spans.",
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"row_span": &schema.Schema{
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1,
+										Description:  "The height of the tile, in multiples of the row height. The row grows to the height of its highest tile, tiles of the rows below are placed beneath it.",
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
 						"tile_filter": &schema.Schema{
 							Type:        schema.TypeList,
 							Description: "A filter applied to a tile, it overrides the dashboard's filter.",
@@ -221,7 +294,7 @@ func resourceDynatraceDashboard() *schema.Resource {
 						"metric": &schema.Schema{
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "The tile is visible and ready to use (true) or just placed on the dashboard (false).",
+							Description: "The metric assigned to the tile, for SLO tiles the visualization settings such as `METRICS=true;LEGEND=true;PROBLEMS=true;decimals=10;`.",
 						},
 						"filter_config": &schema.Schema{
 							Type:        schema.TypeList,
@@ -283,11 +356,70 @@ func resourceDynatraceDashboard() *schema.Resource {
 							Optional:    true,
 							Description: "The limit of the results, if not set will use the default value of the system.",
 						},
+						"name_size": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The size of the tile name, `SMALL`, `MEDIUM` or `LARGE`. Used by header tiles.",
+							ValidateFunc: validation.StringInSlice([]string{"SMALL", "MEDIUM", "LARGE"}, false),
+						},
+						"auto_refresh_disabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "The tile is not refreshed automatically (true).",
+						},
+						"queries": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The metric queries of a data explorer tile, as JSON.",
+							ValidateFunc: validation.StringIsJSON,
+							StateFunc:    normalizeJSONState,
+						},
+						"visual_config": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The visualization of a data explorer tile, as JSON.",
+							ValidateFunc: validation.StringIsJSON,
+							StateFunc:    normalizeJSONState,
+						},
+						"queries_settings": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The settings shared by the queries of a data explorer tile, such as the resolution, as JSON.",
+							ValidateFunc: validation.StringIsJSON,
+							StateFunc:    normalizeJSONState,
+						},
+						"metric_expressions": &schema.Schema{
+							Type:        schema.TypeList,
+							Description: "The metric expressions of a data explorer tile, generated from its queries.",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"image": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The image of an image tile, as a data URL such as `data:image/png;base64,...`.",
+						},
+						"unknowns": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The fields of the tile that no other attribute covers, such as those of problem tiles, as a JSON object. They are sent and read back as they are.",
+							ValidateFunc: validation.StringIsJSON,
+							StateFunc:    normalizeJSONState,
+						},
 					},
 				},
 			},
 		},
 	}
+
+	// tags were a list before version 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		listsToSetsStateUpgrader(0, r.Schema, "dashboard_metadata.tags"),
+	}
+
+	return r
 }
 
 func filterConfigSchema() *schema.Resource {
@@ -446,10 +578,39 @@ func filterConfigSchema() *schema.Resource {
 	}
 }
 
+func resourceDynatraceDashboardCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// values interpolated from other resources are only checked once they are known
+	if !d.NewValueKnown("tile") || !d.NewValueKnown("layout") {
+		return nil
+	}
+
+	tiles := d.Get("tile").([]interface{})
+
+	bounds, err := expandDashboardLayoutBounds(tiles, expandDashboardLayout(d.Get("layout").([]interface{})))
+	if err != nil {
+		return err
+	}
+
+	errs := validateDashboardTileBounds(bounds)
+	if len(errs) != 0 {
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = "  - " + err.Error()
+		}
+
+		return fmt.Errorf("invalid dashboard layout:\n%s", strings.Join(messages, "\n"))
+	}
+
+	return validateOnPlan(d, m, resourceDynatraceDashboard(), "dashboard", "/dashboards", func(d *schema.ResourceData) (interface{}, error) {
+		if len(d.Id()) != 0 {
+			return expandExistingDashboard(d, d.Id())
+		}
+		return expandDashboard(d)
+	})
+}
+
 func resourceDynatraceDashboardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
-	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
-	authConfigV1 := providerConf.AuthConfigV1
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -459,16 +620,22 @@ func resourceDynatraceDashboardCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	dashboard, _, err := dynatraceConfigClientV1.DashboardsApi.CreateDashboard(authConfigV1).Dashboard(*dd).Execute()
+	body, err := json.Marshal(dd)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPost, "/dashboards", body)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create dynatrace dashboard",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace dashboard", err, nil, resourceDynatraceDashboard().Schema)...)
 		return diags
 	}
 
+	var dashboard dynatraceConfigV1.EntityShortRepresentation
+	if err := json.Unmarshal(response, &dashboard); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(dashboard.Id)
 
 	resourceDynatraceDashboardRead(ctx, d, m)
@@ -478,29 +645,36 @@ func resourceDynatraceDashboardCreate(ctx context.Context, d *schema.ResourceDat
 
 func resourceDynatraceDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
-	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
-	authConfigV1 := providerConf.AuthConfigV1
 
 	var diags diag.Diagnostics
 
 	dashboardID := d.Id()
 
-	dashboard, _, err := dynatraceConfigClientV1.DashboardsApi.GetDashboard(authConfigV1, dashboardID).Execute()
+	response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, "/dashboards/"+dashboardID, nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read dynatrace dashboard",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace dashboard", err, nil, nil)...)
 		return diags
 	}
 
+	var dashboard dashboardConfig
+	if err := json.Unmarshal(response, &dashboard); err != nil {
+		return diag.FromErr(err)
+	}
+
 	dashboardMetadata := flattenDashboardMetadata(&dashboard.DashboardMetadata)
 	if err := d.Set("dashboard_metadata", dashboardMetadata); err != nil {
 		return diag.FromErr(err)
 	}
 
 	dashboardTiles := flattenDashboardTilesData(dashboard.Tiles)
+	// the layout of a tile only exists in the configuration and is carried over, the
+	// bounds computed from it are left out
+	for i, tile := range dashboardTiles {
+		if layout, ok := d.GetOk(fmt.Sprintf("tile.%d.layout", i)); ok {
+			tile.(map[string]interface{})["layout"] = layout
+			delete(tile.(map[string]interface{}), "bounds")
+		}
+	}
 	if err := d.Set("tile", dashboardTiles); err != nil {
 		return diag.FromErr(err)
 	}
@@ -510,8 +684,6 @@ func resourceDynatraceDashboardRead(ctx context.Context, d *schema.ResourceData,
 
 func resourceDynatraceDashboardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
-	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
-	authConfigV1 := providerConf.AuthConfigV1
 
 	var diags diag.Diagnostics
 
@@ -524,13 +696,14 @@ func resourceDynatraceDashboardUpdate(ctx context.Context, d *schema.ResourceDat
 			return diag.FromErr(err)
 		}
 
-		_, _, err = dynatraceConfigClientV1.DashboardsApi.UpdateDashboard(authConfigV1, dashboardID).Dashboard(*dd).Execute()
+		body, err := json.Marshal(dd)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, "/dashboards/"+dashboardID, body)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update dynatrace dashboard",
-				Detail:   getErrorMessage(err),
-			})
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace dashboard", err, nil, resourceDynatraceDashboard().Schema)...)
 			return diags
 		}
 	}
@@ -547,13 +720,9 @@ func resourceDynatraceDashboardDelete(ctx context.Context, d *schema.ResourceDat
 
 	dashboardID := d.Id()
 
-	_, err := dynatraceConfigClientV1.DashboardsApi.DeleteDashboard(authConfigV1, dashboardID).Execute()
+	resp, err := dynatraceConfigClientV1.DashboardsApi.DeleteDashboard(authConfigV1, dashboardID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete dynatrace dashbaord",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace dashbaord", err, resp, nil)...)
 		return diags
 	}
 
@@ -561,3 +730,23 @@ func resourceDynatraceDashboardDelete(ctx context.Context, d *schema.ResourceDat
 
 	return diags
 }
+
+func resourceDynatraceDashboardNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	dashboards, _, err := dynatraceConfigClientV1.DashboardsApi.GetDashboardStubsList(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]namedEntity, 0, len(dashboards.Dashboards))
+	for _, dashboard := range dashboards.Dashboards {
+		if dashboard.Name != nil {
+			entities = append(entities, namedEntity{id: dashboard.Id, name: *dashboard.Name})
+		}
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,166 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDynatraceDashboardJSON() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDynatraceDashboardJSONCreate,
+		ReadContext:   resourceDynatraceDashboardJSONRead,
+		UpdateContext: resourceDynatraceDashboardJSONUpdate,
+		DeleteContext: resourceDynatraceDashboardJSONDelete,
+		CustomizeDiff: resourceDynatraceDashboardJSONCustomizeDiff,
+		Importer:      importStateByName("dashboard", resourceDynatraceDashboardNames),
+
+		Schema: map[string]*schema.Schema{
+			"contents": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The dashboard as JSON, for example as exported from the Dynatrace UI. The id, metadata and dashboardMetadata.owner fields are assigned by the server and ignored, so are the fields the server adds to the configured contents.",
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				StateFunc: func(val interface{}) string {
+					contents, err := normalizeDashboardJSON(val.(string))
+					if err != nil {
+						return val.(string)
+					}
+					return contents
+				},
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The name of the dashboard.",
+				Computed:    true,
+			},
+			"owner": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The owner of the dashboard, as assigned by the server.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDynatraceDashboardJSONCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateOnPlan(d, m, resourceDynatraceDashboardJSON(), "dashboard", "/dashboards", func(d *schema.ResourceData) (interface{}, error) {
+		body, err := expandDashboardJSON(d.Get("contents").(string), d.Id())
+		return json.RawMessage(body), err
+	})
+}
+
+func resourceDynatraceDashboardJSONCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	body, err := expandDashboardJSON(d.Get("contents").(string), "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPost, "/dashboards", body)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace dashboard", err, nil, resourceDynatraceDashboardJSON().Schema)...)
+		return diags
+	}
+
+	var dashboard dynatraceConfigV1.EntityShortRepresentation
+	if err := json.Unmarshal(response, &dashboard); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dashboard.Id)
+
+	return resourceDynatraceDashboardJSONRead(ctx, d, m)
+}
+
+func resourceDynatraceDashboardJSONRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	var diags diag.Diagnostics
+
+	dashboardID := d.Id()
+
+	response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, "/dashboards/"+dashboardID, nil)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace dashboard", err, nil, nil)...)
+		return diags
+	}
+
+	contents, err := normalizeDashboardJSON(string(response))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// fields the server adds to the configured contents are only kept when nothing
+	// was configured yet, e.g. on import
+	if configured, ok := d.Get("contents").(string); ok && len(configured) != 0 {
+		if contents, err = projectDashboardJSON(contents, configured); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("contents", contents); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var dashboard dynatraceConfigV1.Dashboard
+	if err := json.Unmarshal(response, &dashboard); err == nil {
+		d.Set("name", dashboard.DashboardMetadata.Name)
+		d.Set("owner", dashboard.DashboardMetadata.Owner)
+	}
+
+	return diags
+}
+
+func resourceDynatraceDashboardJSONUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	var diags diag.Diagnostics
+
+	dashboardID := d.Id()
+
+	if d.HasChange("contents") {
+
+		body, err := expandDashboardJSON(d.Get("contents").(string), dashboardID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, "/dashboards/"+dashboardID, body)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace dashboard", err, nil, resourceDynatraceDashboardJSON().Schema)...)
+			return diags
+		}
+	}
+
+	return resourceDynatraceDashboardJSONRead(ctx, d, m)
+}
+
+func resourceDynatraceDashboardJSONDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	var diags diag.Diagnostics
+
+	dashboardID := d.Id()
+
+	resp, err := dynatraceConfigClientV1.DashboardsApi.DeleteDashboard(authConfigV1, dashboardID).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace dashboard", err, resp, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,100 @@
+package dynatrace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDynatraceDashboardJSON_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s.dynatrace", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	name := fmt.Sprintf("%s", rName)
+	resourceName := "dynatrace_dashboard_json.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDynatraceDashboardJSONDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceDashboardJSONConfig(name, "Hello"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceDashboardExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "owner"),
+				),
+			},
+			{
+				Config: testAccDynatraceDashboardJSONConfig(name, "Hello again"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceDashboardExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynatraceDashboardJSONDestroy(s *terraform.State) error {
+	providerConf := testAccProvider.Meta().(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dynatrace_dashboard_json" {
+			continue
+		}
+
+		dashboardID := rs.Primary.ID
+
+		dashboard, _, err := dynatraceConfigClientV1.DashboardsApi.GetDashboard(authConfigV1, dashboardID).Execute()
+		if err == nil {
+			if dashboard.Id == &rs.Primary.ID {
+				return fmt.Errorf("Dashboard still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccDynatraceDashboardJSONConfig(name string, markdown string) string {
+	return fmt.Sprintf(`resource "dynatrace_dashboard_json" "test" {
+		contents = jsonencode({
+		  metadata = {
+			configurationVersions = [3]
+			clusterVersion        = "1.220.0"
+		  }
+		  dashboardMetadata = {
+			name   = "%s"
+			shared = false
+			owner  = "someone@example.com"
+		  }
+		  tiles = [
+			{
+			  name       = "Markdown"
+			  tileType   = "MARKDOWN"
+			  configured = true
+			  bounds = {
+				top    = 0
+				left   = 0
+				width  = 304
+				height = 152
+			  }
+			  tileFilter = {}
+			  markdown   = "%s"
+			}
+		  ]
+		})
+	  }
+`, name, markdown)
+}
@@ -0,0 +1,184 @@
+package dynatrace
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDynatraceDashboardReport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDynatraceDashboardReportCreate,
+		ReadContext:   resourceDynatraceDashboardReportRead,
+		UpdateContext: resourceDynatraceDashboardReportUpdate,
+		DeleteContext: resourceDynatraceDashboardReportDelete,
+		Importer:      importStateByName("dashboard report", resourceDynatraceDashboardReportNames),
+
+		Schema: map[string]*schema.Schema{
+			"dashboard_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The ID of the associated dashboard.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "The email notifications for the dashboard report are enabled (true) or disabled (false).",
+				Optional:    true,
+				Default:     true,
+			},
+			"subscriptions": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The recipients of the report, as email addresses or Dynatrace user IDs.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"week": &schema.Schema{
+							Type:        schema.TypeList,
+							Description: "The weekly subscribers, they receive the report every Monday at midnight.",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"month": &schema.Schema{
+							Type:        schema.TypeList,
+							Description: "The monthly subscribers, they receive the report on the first Monday of the month at midnight.",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDynatraceDashboardReportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	dr, err := expandDashboardReport(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	report, resp, err := dynatraceConfigClientV1.ReportsApi.CreateReport(authConfigV1).DashboardReport(*dr).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace dashboard report", err, resp, resourceDynatraceDashboardReport().Schema)...)
+		return diags
+	}
+
+	d.SetId(report.Id)
+
+	return resourceDynatraceDashboardReportRead(ctx, d, m)
+}
+
+func resourceDynatraceDashboardReportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	var diags diag.Diagnostics
+
+	reportID := d.Id()
+
+	report, resp, err := dynatraceConfigClientV1.ReportsApi.GetReport(authConfigV1, reportID).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace dashboard report", err, resp, nil)...)
+		return diags
+	}
+
+	d.Set("dashboard_id", report.DashboardId)
+	d.Set("enabled", report.GetEnabled())
+
+	if err := d.Set("subscriptions", flattenDashboardReportSubscriptions(&report.Subscriptions)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDynatraceDashboardReportUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	var diags diag.Diagnostics
+
+	reportID := d.Id()
+
+	if d.HasChange("enabled") || d.HasChange("subscriptions") {
+
+		dr, err := expandDashboardReport(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		dr.SetId(reportID)
+
+		_, resp, err := dynatraceConfigClientV1.ReportsApi.CreateOrUpdateReport(authConfigV1, reportID).DashboardReport(*dr).Execute()
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace dashboard report", err, resp, resourceDynatraceDashboardReport().Schema)...)
+			return diags
+		}
+	}
+
+	return resourceDynatraceDashboardReportRead(ctx, d, m)
+}
+
+func resourceDynatraceDashboardReportDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	var diags diag.Diagnostics
+
+	reportID := d.Id()
+
+	resp, err := dynatraceConfigClientV1.ReportsApi.DeleteReport(authConfigV1, reportID).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace dashboard report", err, resp, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceDynatraceDashboardReportNames lists reports under the names of their dashboards.
+func resourceDynatraceDashboardReportNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	dashboards, err := resourceDynatraceDashboardNames(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, _, err := dynatraceConfigClientV1.ReportsApi.ListReports(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	entities := []namedEntity{}
+	for _, report := range reports.Values {
+		for _, dashboard := range dashboards {
+			if dashboard.id == report.DashboardId {
+				entities = append(entities, namedEntity{id: report.Id, name: dashboard.name})
+			}
+		}
+	}
+
+	return entities, nil
+}
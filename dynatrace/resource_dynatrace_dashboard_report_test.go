@@ -0,0 +1,119 @@
+package dynatrace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDynatraceDashboardReport_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s.dynatrace", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "dynatrace_dashboard_report.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDynatraceDashboardReportDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceDashboardReportConfig(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceDashboardReportExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "dashboard_id", "dynatrace_dashboard.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "subscriptions.0.week.0", "leadership@example.com"),
+				),
+			},
+			{
+				Config: testAccDynatraceDashboardReportConfig(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceDashboardReportExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynatraceDashboardReportDestroy(s *terraform.State) error {
+	providerConf := testAccProvider.Meta().(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dynatrace_dashboard_report" {
+			continue
+		}
+
+		reportID := rs.Primary.ID
+
+		report, _, err := dynatraceConfigClientV1.ReportsApi.GetReport(authConfigV1, reportID).Execute()
+		if err == nil {
+			if report.GetId() == rs.Primary.ID {
+				return fmt.Errorf("Dashboard report still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynatraceDashboardReportExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		providerConf := testAccProvider.Meta().(*ProviderConfiguration)
+		dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+		authConfigV1 := providerConf.AuthConfigV1
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		_, _, err := dynatraceConfigClientV1.ReportsApi.GetReport(authConfigV1, rs.Primary.ID).Execute()
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccDynatraceDashboardReportConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`resource "dynatrace_dashboard" "test" {
+		dashboard_metadata {
+			name = "%s"
+			shared = true
+		}
+
+		tile {
+			name = "Infrastructure"
+			tile_type = "HEADER"
+			configured = true
+			bounds {
+				top = 0
+				left = 0
+				width = 304
+				height = 38
+			}
+		}
+	}
+
+	resource "dynatrace_dashboard_report" "test" {
+		dashboard_id = dynatrace_dashboard.test.id
+		enabled = %t
+		subscriptions {
+			week = ["leadership@example.com"]
+			month = ["board@example.com"]
+		}
+	}
+	  `, name, enabled)
+}
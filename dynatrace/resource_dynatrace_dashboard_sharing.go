@@ -0,0 +1,207 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDynatraceDashboardSharing() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDynatraceDashboardSharingCreate,
+		ReadContext:   resourceDynatraceDashboardSharingRead,
+		UpdateContext: resourceDynatraceDashboardSharingUpdate,
+		DeleteContext: resourceDynatraceDashboardSharingDelete,
+		Importer:      importStateByName("dashboard", resourceDynatraceDashboardNames),
+
+		Schema: map[string]*schema.Schema{
+			"dashboard_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The ID of the dashboard to share.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "The dashboard is shared (true) or private (false).",
+				Optional:    true,
+				Default:     true,
+			},
+			"preset": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "The dashboard is a preset (true).",
+				Computed:    true,
+			},
+			"permission": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Access permissions of the dashboard.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The type of the permission, `USER`, `GROUP` or `ALL` for everybody with the link.",
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"USER", "GROUP", "ALL"}, false),
+						},
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The ID of the user or group the permission is granted to. Not set for `ALL`.",
+							Optional:    true,
+						},
+						"permission": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The level of the permission, `VIEW` or `EDIT`.",
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"VIEW", "EDIT"}, false),
+						},
+					},
+				},
+			},
+			"public_access": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Anonymous access to the dashboard. Without it the dashboard is not public.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"management_zone_ids": &schema.Schema{
+							Type:        schema.TypeList,
+							Description: "The management zones the anonymous users are restricted to, each one has its own public URL.",
+							Required:    true,
+							MinItems:    1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"urls": &schema.Schema{
+							Type:        schema.TypeMap,
+							Description: "The public URLs of the dashboard by management zone ID.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDynatraceDashboardSharingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	dashboardID := d.Get("dashboard_id").(string)
+
+	body, err := json.Marshal(expandDashboardSharing(d, dashboardID))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, "/dashboards/"+dashboardID+"/shareSettings", body)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace dashboard sharing", err, nil, resourceDynatraceDashboardSharing().Schema)...)
+		return diags
+	}
+
+	d.SetId(dashboardID)
+
+	return resourceDynatraceDashboardSharingRead(ctx, d, m)
+}
+
+func resourceDynatraceDashboardSharingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	var diags diag.Diagnostics
+
+	dashboardID := d.Id()
+
+	response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, "/dashboards/"+dashboardID+"/shareSettings", nil)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace dashboard sharing", err, nil, nil)...)
+		return diags
+	}
+
+	var sharing dashboardSharing
+	if err := json.Unmarshal(response, &sharing); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("dashboard_id", dashboardID)
+	d.Set("enabled", sharing.Enabled)
+	d.Set("preset", sharing.Preset)
+
+	if err := d.Set("permission", flattenDashboardSharingPermissions(sharing.Permissions)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("public_access", flattenDashboardSharingPublicAccess(&sharing.PublicAccess)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDynatraceDashboardSharingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	var diags diag.Diagnostics
+
+	dashboardID := d.Id()
+
+	if d.HasChange("enabled") || d.HasChange("permission") || d.HasChange("public_access") {
+
+		body, err := json.Marshal(expandDashboardSharing(d, dashboardID))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, "/dashboards/"+dashboardID+"/shareSettings", body)
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace dashboard sharing", err, nil, resourceDynatraceDashboardSharing().Schema)...)
+			return diags
+		}
+	}
+
+	return resourceDynatraceDashboardSharingRead(ctx, d, m)
+}
+
+// resourceDynatraceDashboardSharingDelete makes the dashboard private again, the
+// sharing configuration itself lives as long as the dashboard.
+func resourceDynatraceDashboardSharingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	var diags diag.Diagnostics
+
+	dashboardID := d.Id()
+
+	body, err := json.Marshal(&dashboardSharing{
+		Id:          dashboardID,
+		Enabled:     false,
+		Permissions: []dashboardSharingPermission{},
+		PublicAccess: dashboardSharingPublicAccess{
+			ManagementZoneIds: []string{},
+		},
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, "/dashboards/"+dashboardID+"/shareSettings", body)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace dashboard sharing", err, nil, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,95 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDynatraceDashboardSharing_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s.dynatrace", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "dynatrace_dashboard_sharing.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDynatraceDashboardSharingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceDashboardSharingConfig(rName, "VIEW"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "permission.0.type", "ALL"),
+					resource.TestCheckResourceAttr(resourceName, "permission.0.permission", "VIEW"),
+				),
+			},
+			{
+				Config: testAccDynatraceDashboardSharingConfig(rName, "EDIT"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "permission.0.permission", "EDIT"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDynatraceDashboardSharingDestroy(s *terraform.State) error {
+	providerConf := testAccProvider.Meta().(*ProviderConfiguration)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dynatrace_dashboard_sharing" {
+			continue
+		}
+
+		response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, "/dashboards/"+rs.Primary.ID+"/shareSettings", nil)
+		if err == nil {
+			var sharing dashboardSharing
+			if err := json.Unmarshal(response, &sharing); err == nil && sharing.Enabled {
+				return fmt.Errorf("Dashboard still shared: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccDynatraceDashboardSharingConfig(name string, permission string) string {
+	return fmt.Sprintf(`resource "dynatrace_dashboard" "test" {
+		dashboard_metadata {
+			name = "%s"
+			shared = true
+		}
+
+		tile {
+			name = "Infrastructure"
+			tile_type = "HEADER"
+			configured = true
+			bounds {
+				top = 0
+				left = 0
+				width = 304
+				height = 38
+			}
+		}
+	}
+
+	resource "dynatrace_dashboard_sharing" "test" {
+		dashboard_id = dynatrace_dashboard.test.id
+		enabled = true
+		permission {
+			type = "ALL"
+			permission = "%s"
+		}
+	}
+	  `, name, permission)
+}
@@ -48,6 +48,28 @@ func TestAccDynatraceDashboard_basic(t *testing.T) {
 	})
 }
 
+func TestAccDynatraceDashboard_layout(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s.dynatrace", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "dynatrace_dashboard.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDynatraceDashboardDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceDashboardConfigLayout(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceDashboardExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tile.1.bounds.0.top", "38"),
+					resource.TestCheckResourceAttr(resourceName, "tile.1.bounds.0.width", "304"),
+					resource.TestCheckResourceAttr(resourceName, "tile.2.bounds.0.left", "304"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDynatraceDashboardDestroy(s *terraform.State) error {
 	providerConf := testAccProvider.Meta().(*ProviderConfiguration)
 	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
@@ -209,3 +231,51 @@ func testAccDynatraceDashboardConfigModified(name string) string {
 	}
 	  `, name)
 }
+
+func testAccDynatraceDashboardConfigLayout(name string) string {
+	return fmt.Sprintf(`resource "dynatrace_dashboard" "test" {
+		dashboard_metadata {
+			name = "%s"
+			shared = false
+		}
+
+		layout {
+			top = 38
+			column_width = 152
+			row_height = 152
+		}
+
+		tile {
+			name = "Infrastructure"
+			tile_type = "HEADER"
+			configured = true
+			bounds {
+				top = 0
+				left = 0
+				width = 456
+				height = 38
+			}
+		}
+
+		tile {
+			name = "Markdown"
+			tile_type = "MARKDOWN"
+			configured = true
+			markdown = "## Hosts"
+			layout {
+				row = 0
+				column_span = 2
+			}
+		}
+
+		tile {
+			name = "Problems"
+			tile_type = "OPEN_PROBLEMS"
+			configured = true
+			layout {
+				row = 0
+			}
+		}
+	}
+	  `, name)
+}
@@ -8,14 +8,13 @@ import (
 )
 
 func resourceDynatraceEnvironment() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceEnvironmentCreate,
 		ReadContext:   resourceDynatraceEnvironmentRead,
 		UpdateContext: resourceDynatraceEnvironmentUpdate,
 		DeleteContext: resourceDynatraceEnvironmentDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer:      importStateByName("environment", resourceDynatraceEnvironmentNames),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -47,7 +46,7 @@ func resourceDynatraceEnvironment() *schema.Resource {
 				Computed:    true,
 			},
 			"tags": &schema.Schema{
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Description: "A set of tags that are assigned to this environment. Every tag can have a maximum length of 100 characters.",
 				Optional:    true,
 				Elem: &schema.Schema{
@@ -575,6 +574,13 @@ func resourceDynatraceEnvironment() *schema.Resource {
 			},
 		},
 	}
+
+	// tags were a list before version 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		listsToSetsStateUpgrader(0, r.Schema, "tags"),
+	}
+
+	return r
 }
 
 func resourceDynatraceEnvironmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -592,13 +598,9 @@ func resourceDynatraceEnvironmentCreate(ctx context.Context, d *schema.ResourceD
 
 	createToken := d.Get("create_token").(bool)
 
-	environment, _, err := dynatraceClusterV2.EnvironmentsApi.CreateEnvironment(authClusterV2).Environment(*en).CreateToken(createToken).Execute()
+	environment, resp, err := dynatraceClusterV2.EnvironmentsApi.CreateEnvironment(authClusterV2).Environment(*en).CreateToken(createToken).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Dynatrace environment",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create Dynatrace environment", err, resp, resourceDynatraceEnvironment().Schema)...)
 		return diags
 	}
 
@@ -621,13 +623,9 @@ func resourceDynatraceEnvironmentRead(ctx context.Context, d *schema.ResourceDat
 
 	environmentID := d.Id()
 
-	environment, _, err := dynatraceClusterV2.EnvironmentsApi.GetSingleEnvironment(authClusterV2, environmentID).Execute()
+	environment, resp, err := dynatraceClusterV2.EnvironmentsApi.GetSingleEnvironment(authClusterV2, environmentID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read Dynatrace environment",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read Dynatrace environment", err, resp, nil)...)
 		return diags
 	}
 
@@ -654,13 +652,9 @@ func resourceDynatraceEnvironmentUpdate(ctx context.Context, d *schema.ResourceD
 
 	createToken := d.Get("create_token").(bool)
 
-	_, _, err = dynatraceClusterV2.EnvironmentsApi.CreateOrUpdateEnvironment(authClusterV2, environmentID).Environment(*en).CreateToken(createToken).Execute()
+	_, resp, err := dynatraceClusterV2.EnvironmentsApi.CreateOrUpdateEnvironment(authClusterV2, environmentID).Environment(*en).CreateToken(createToken).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update Dynatrace environment",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to update Dynatrace environment", err, resp, resourceDynatraceEnvironment().Schema)...)
 		return diags
 	}
 
@@ -678,13 +672,9 @@ func resourceDynatraceEnvironmentDelete(ctx context.Context, d *schema.ResourceD
 
 	environmentID := d.Id()
 
-	_, err := dynatraceClusterV2.EnvironmentsApi.DeleteEnvironment(authClusterV2, environmentID).Execute()
+	resp, err := dynatraceClusterV2.EnvironmentsApi.DeleteEnvironment(authClusterV2, environmentID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete Dynatrace environment",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete Dynatrace environment", err, resp, nil)...)
 		return diags
 	}
 
@@ -693,3 +683,35 @@ func resourceDynatraceEnvironmentDelete(ctx context.Context, d *schema.ResourceD
 	return diags
 
 }
+
+func resourceDynatraceEnvironmentNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceClusterV2 := providerConf.DynatraceClusterClientV2
+	authClusterV2 := providerConf.AuthClusterV2
+
+	entities := []namedEntity{}
+	err := listPages(func(nextPageKey string) (*string, error) {
+		request := dynatraceClusterV2.EnvironmentsApi.GetAllEnvironments(authClusterV2)
+		if len(nextPageKey) != 0 {
+			request = request.NextPageKey(nextPageKey)
+		}
+
+		environments, _, err := request.Execute()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, environment := range environments.Environments {
+			if environment.Id != nil {
+				entities = append(entities, namedEntity{id: *environment.Id, name: environment.Name})
+			}
+		}
+
+		return environments.NextPageKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
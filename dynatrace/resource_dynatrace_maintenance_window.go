@@ -2,21 +2,25 @@ package dynatrace
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDynatraceMaintenanceWindow() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceMaintenanceWindowCreate,
 		ReadContext:   resourceDynatraceMaintenanceWindowRead,
 		UpdateContext: resourceDynatraceMaintenanceWindowUpdate,
 		DeleteContext: resourceDynatraceMaintenanceWindowDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: resourceDynatraceMaintenanceWindowCustomizeDiff,
+		Importer:      importStateByName("maintenance window", resourceDynatraceMaintenanceWindowNames),
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -24,6 +28,7 @@ func resourceDynatraceMaintenanceWindow() *schema.Resource {
 				Required:    true,
 				Description: "The name of the maintenance window, displayed in the UI.",
 			},
+			"api": apiSchema(),
 			"description": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
@@ -118,24 +123,28 @@ func resourceDynatraceMaintenanceWindow() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"recurrence_type": &schema.Schema{
-							Type:        schema.TypeString,
-							Description: "The type of the schedule recurrence.",
-							Required:    true,
+							Type:         schema.TypeString,
+							Description:  "The type of the schedule recurrence: ONCE, DAILY, WEEKLY or MONTHLY.",
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"ONCE", "DAILY", "WEEKLY", "MONTHLY"}, false),
 						},
 						"start": &schema.Schema{
-							Type:        schema.TypeString,
-							Description: "The start date and time of the maintenance window validity period in yyyy-mm-dd HH:mm format.",
-							Required:    true,
+							Type:         schema.TypeString,
+							Description:  "The start date and time of the maintenance window validity period in yyyy-mm-dd HH:mm format.",
+							Required:     true,
+							ValidateFunc: validateMaintenanceWindowTime,
 						},
 						"end": &schema.Schema{
-							Type:        schema.TypeString,
-							Description: "The end date and time of the maintenance window validity period in yyyy-mm-dd HH:mm format.",
-							Required:    true,
+							Type:         schema.TypeString,
+							Description:  "The end date and time of the maintenance window validity period in yyyy-mm-dd HH:mm format.",
+							Required:     true,
+							ValidateFunc: validateMaintenanceWindowTime,
 						},
 						"zone_id": &schema.Schema{
-							Type:        schema.TypeString,
-							Description: "The time zone of the start and end time. Default time zone is UTC.",
-							Required:    true,
+							Type:         schema.TypeString,
+							Description:  "The time zone of the start and end time, either an IANA time zone (Europe/Vienna) or an UTC offset (UTC+01:00). Default time zone is UTC.",
+							Required:     true,
+							ValidateFunc: validateMaintenanceWindowZone,
 						},
 						"recurrence": &schema.Schema{
 							Type:        schema.TypeList,
@@ -144,24 +153,28 @@ func resourceDynatraceMaintenanceWindow() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"day_of_week": &schema.Schema{
-										Type:        schema.TypeString,
-										Description: "The day of the week for weekly maintenance.",
-										Optional:    true,
+										Type:         schema.TypeString,
+										Description:  "The day of the week for weekly maintenance.",
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"}, false),
 									},
 									"day_of_month": &schema.Schema{
-										Type:        schema.TypeInt,
-										Description: "The day of the month for monthly maintenance.",
-										Optional:    true,
+										Type:         schema.TypeInt,
+										Description:  "The day of the month for monthly maintenance.",
+										Optional:     true,
+										ValidateFunc: validation.IntBetween(1, 31),
 									},
 									"start_time": &schema.Schema{
-										Type:        schema.TypeString,
-										Description: "The start time of the maintenance window in HH:mm format.",
-										Required:    true,
+										Type:         schema.TypeString,
+										Description:  "The start time of the maintenance window in HH:mm format.",
+										Required:     true,
+										ValidateFunc: validateMaintenanceWindowClock,
 									},
 									"duration_minutes": &schema.Schema{
-										Type:        schema.TypeInt,
-										Description: "The duration of the maintenance window in minutes.",
-										Required:    true,
+										Type:         schema.TypeInt,
+										Description:  "The duration of the maintenance window in minutes.",
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
 									},
 								},
 							},
@@ -169,8 +182,118 @@ func resourceDynatraceMaintenanceWindow() *schema.Resource {
 					},
 				},
 			},
+			"next_occurrences_count": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The number of upcoming occurrences of the schedule to compute into next_occurrences. Defaults to 5.",
+				Optional:     true,
+				Default:      maintenanceWindowDefaultOccurrences,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+			"next_start": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The RFC 3339 start of the current or next occurrence of the schedule, empty if the schedule has elapsed. Computed from the schedule when the resource is read.",
+				Computed:    true,
+			},
+			"next_end": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The RFC 3339 end of the current or next occurrence of the schedule, empty if the schedule has elapsed. Computed from the schedule when the resource is read.",
+				Computed:    true,
+			},
+			"next_occurrences": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "The current and upcoming occurrences of the schedule, limited to next_occurrences_count. Computed from the schedule when the resource is read.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The RFC 3339 start of the occurrence.",
+							Computed:    true,
+						},
+						"end": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The RFC 3339 end of the occurrence.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"active_now": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Whether the maintenance window was active when the resource was last read.",
+				Computed:    true,
+			},
 		},
 	}
+
+	// IDs were config v1 IDs before version 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		settingsAPIStateUpgrader(0, r.Schema, maintenanceWindowSettings),
+	}
+
+	return r
+}
+
+// maintenanceWindowSettings manages maintenance windows through builtin:alerting.maintenance-window settings objects.
+var maintenanceWindowSettings = &settingsAPIResource{
+	description: "maintenance window",
+	schemaID:    "builtin:alerting.maintenance-window",
+	nameOf: func(value map[string]interface{}) string {
+		generalProperties, _ := value["generalProperties"].(map[string]interface{})
+		return settingsValueName(generalProperties)
+	},
+	list: resourceDynatraceMaintenanceWindowNames,
+}
+
+func resourceDynatraceMaintenanceWindowCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// values interpolated from other resources are only checked once they are known
+	for _, key := range []string{
+		"schedule.0.recurrence_type",
+		"schedule.0.start",
+		"schedule.0.end",
+		"schedule.0.zone_id",
+		"schedule.0.recurrence",
+		"schedule.0.recurrence.0.day_of_week",
+		"schedule.0.recurrence.0.day_of_month",
+		"schedule.0.recurrence.0.start_time",
+		"schedule.0.recurrence.0.duration_minutes",
+	} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	schedule := expandMaintenanceWindowSchedule(d.Get("schedule").([]interface{}))
+
+	errs := validateMaintenanceWindowSchedule(&schedule)
+	if len(errs) == 0 {
+		if d.Id() != "" && (d.HasChange("schedule") || d.HasChange("next_occurrences_count")) {
+			for _, key := range []string{"next_start", "next_end", "next_occurrences", "active_now"} {
+				if err := d.SetNewComputed(key); err != nil {
+					return err
+				}
+			}
+		}
+
+		if plansSettingsAPI(d, m) {
+			if schedule.Recurrence != nil && schedule.RecurrenceType != "ONCE" && schedule.Recurrence.DurationMinutes >= 24*60 {
+				return fmt.Errorf("invalid maintenance window schedule:\n  - schedule.0.recurrence.0.duration_minutes: must be less than 1440 with the Settings 2.0 API, got %d", schedule.Recurrence.DurationMinutes)
+			}
+
+			return nil
+		}
+
+		return validateOnPlan(d, m, resourceDynatraceMaintenanceWindow(), "maintenance window", "/maintenanceWindows", func(d *schema.ResourceData) (interface{}, error) {
+			return expandMaintenanceWindow(d)
+		})
+	}
+
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = "  - " + err.Error()
+	}
+
+	return fmt.Errorf("invalid maintenance window schedule:\n%s", strings.Join(messages, "\n"))
 }
 
 func resourceDynatraceMaintenanceWindowCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -181,18 +304,30 @@ func resourceDynatraceMaintenanceWindowCreate(ctx context.Context, d *schema.Res
 
 	var diags diag.Diagnostics
 
+	if usesSettingsAPI(d, providerConf) {
+		value, err := expandMaintenanceWindowSettings(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		maintenanceWindowID, diags := maintenanceWindowSettings.create(providerConf, value, resourceDynatraceMaintenanceWindow().Schema)
+		if diags.HasError() {
+			return diags
+		}
+
+		d.SetId(maintenanceWindowID)
+
+		return resourceDynatraceMaintenanceWindowRead(ctx, d, m)
+	}
+
 	mw, err := expandMaintenanceWindow(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	maintenanceWindow, _, err := dynatraceConfigClientV1.MaintenanceWindowsApi.CreateMaintenanceWindow(authConfigV1).MaintenanceWindow(*mw).Execute()
+	maintenanceWindow, resp, err := dynatraceConfigClientV1.MaintenanceWindowsApi.CreateMaintenanceWindow(authConfigV1).MaintenanceWindow(*mw).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create dynatrace maintenance window",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace maintenance window", err, resp, resourceDynatraceMaintenanceWindow().Schema)...)
 		return diags
 	}
 
@@ -211,16 +346,37 @@ func resourceDynatraceMaintenanceWindowRead(ctx context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
+	if err := maintenanceWindowSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace maintenance window", err, nil, nil)...)
+		return diags
+	}
+
 	maintenanceWindowID := d.Id()
 
-	maintenaceWindow, _, err := dynatraceConfigClientV1.MaintenanceWindowsApi.GetMaintenanceWindow(authConfigV1, maintenanceWindowID).Execute()
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read dynatrace maintenance window",
-			Detail:   getErrorMessage(err),
-		})
-		return diags
+	var maintenaceWindow *dynatraceConfigV1.MaintenanceWindow
+	if usesSettingsAPI(d, providerConf) {
+		value, diags := maintenanceWindowSettings.read(providerConf, maintenanceWindowID)
+		if diags.HasError() {
+			return diags
+		}
+
+		prior := expandMaintenanceWindowSchedule(d.Get("schedule").([]interface{}))
+		priorScope := expandMaintenanceWindowScope(d.Get("scope").([]interface{}))
+
+		mw, err := flattenMaintenanceWindowSettings(value, &prior, &priorScope)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		maintenaceWindow = mw
+	} else {
+		mw, resp, err := dynatraceConfigClientV1.MaintenanceWindowsApi.GetMaintenanceWindow(authConfigV1, maintenanceWindowID).Execute()
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace maintenance window", err, resp, nil)...)
+			return diags
+		}
+
+		maintenaceWindow = &mw
 	}
 
 	maintenaceWindowScope := flattenMaintenanceWindowScopeData(maintenaceWindow.Scope)
@@ -233,6 +389,14 @@ func resourceDynatraceMaintenanceWindowRead(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
+	if err := setMaintenanceWindowOccurrences(d, &maintenaceWindow.Schedule, time.Now()); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to compute maintenance window occurrences",
+			Detail:   err.Error(),
+		})
+	}
+
 	d.Set("name", &maintenaceWindow.Name)
 	d.Set("description", &maintenaceWindow.Description)
 	d.Set("type", &maintenaceWindow.Type)
@@ -249,8 +413,28 @@ func resourceDynatraceMaintenanceWindowUpdate(ctx context.Context, d *schema.Res
 
 	var diags diag.Diagnostics
 
+	if err := maintenanceWindowSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace maintenance window", err, nil, nil)...)
+		return diags
+	}
+
 	maintenanceWindowID := d.Id()
 
+	if usesSettingsAPI(d, providerConf) {
+		if d.HasChange("name") || d.HasChange("description") || d.HasChange("type") || d.HasChange("suppression") || d.HasChange("scope") || d.HasChange("schedule") {
+			value, err := expandMaintenanceWindowSettings(d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if diags := maintenanceWindowSettings.update(providerConf, maintenanceWindowID, value, resourceDynatraceMaintenanceWindow().Schema); diags.HasError() {
+				return diags
+			}
+		}
+
+		return resourceDynatraceMaintenanceWindowRead(ctx, d, m)
+	}
+
 	if d.HasChange("name") || d.HasChange("description") || d.HasChange("type") || d.HasChange("suppression") || d.HasChange("scope") || d.HasChange("schedule") {
 
 		mw, err := expandMaintenanceWindow(d)
@@ -258,13 +442,9 @@ func resourceDynatraceMaintenanceWindowUpdate(ctx context.Context, d *schema.Res
 			return diag.FromErr(err)
 		}
 
-		_, _, err = dynatraceConfigClientV1.MaintenanceWindowsApi.UpdateMaintenanceWindow(authConfigV1, maintenanceWindowID).MaintenanceWindow(*mw).Execute()
+		_, resp, err := dynatraceConfigClientV1.MaintenanceWindowsApi.UpdateMaintenanceWindow(authConfigV1, maintenanceWindowID).MaintenanceWindow(*mw).Execute()
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update dynatrace maintenance window",
-				Detail:   getErrorMessage(err),
-			})
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace maintenance window", err, resp, resourceDynatraceMaintenanceWindow().Schema)...)
 			return diags
 		}
 
@@ -281,15 +461,26 @@ func resourceDynatraceMaintenanceWindowDelete(ctx context.Context, d *schema.Res
 
 	var diags diag.Diagnostics
 
+	if err := maintenanceWindowSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete maintenance window", err, nil, nil)...)
+		return diags
+	}
+
 	maintenanceWindowID := d.Id()
 
-	_, err := dynatraceConfigClientV1.MaintenanceWindowsApi.DeleteMaintenanceWindow(authConfigV1, maintenanceWindowID).Execute()
+	if usesSettingsAPI(d, providerConf) {
+		if diags := maintenanceWindowSettings.delete(providerConf, maintenanceWindowID); diags.HasError() {
+			return diags
+		}
+
+		d.SetId("")
+
+		return diags
+	}
+
+	resp, err := dynatraceConfigClientV1.MaintenanceWindowsApi.DeleteMaintenanceWindow(authConfigV1, maintenanceWindowID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete maintenance window",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete maintenance window", err, resp, nil)...)
 		return diags
 	}
 
@@ -298,3 +489,16 @@ func resourceDynatraceMaintenanceWindowDelete(ctx context.Context, d *schema.Res
 	return diags
 
 }
+
+func resourceDynatraceMaintenanceWindowNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	stubs, _, err := dynatraceConfigClientV1.MaintenanceWindowsApi.ListMaintenanceWindows(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return namedEntitiesFromStubs(stubs.Values), nil
+}
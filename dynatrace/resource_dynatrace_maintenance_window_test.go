@@ -39,6 +39,7 @@ func TestAccDynatraceMaintenanceWindow_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "scope.0.match.0.type"),
 					resource.TestCheckResourceAttrSet(resourceName, "schedule.0.recurrence_type"),
 					resource.TestCheckResourceAttr(resourceName, "type", "UNPLANNED"),
+					resource.TestCheckResourceAttrSet(resourceName, "active_now"),
 				),
 			},
 			{
@@ -2,20 +2,24 @@ package dynatrace
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDynatraceManagementZone() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceManagementZoneCreate,
 		ReadContext:   resourceDynatraceManagementZoneRead,
 		UpdateContext: resourceDynatraceManagementZoneUpdate,
 		DeleteContext: resourceDynatraceManagementZoneDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: resourceDynatraceManagementZoneCustomizeDiff,
+		Importer:      importStateByName("management zone", resourceDynatraceManagementZoneNames),
+		SchemaVersion: 2,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -23,6 +27,13 @@ func resourceDynatraceManagementZone() *schema.Resource {
 				Description: "The name of the management zone.",
 				Required:    true,
 			},
+			"adopt_existing": &schema.Schema{
+				Type:        schema.TypeBool,
+				Description: "Take over an existing management zone with the same name instead of failing to create it. The management zone is updated to match the configuration.",
+				Optional:    true,
+				Default:     false,
+			},
+			"api": apiSchema(),
 			"rule": &schema.Schema{
 				Type:        schema.TypeList,
 				Description: "A list of rules for management zone usage. Each rule is evaluated independently of all other rules.",
@@ -40,7 +51,7 @@ func resourceDynatraceManagementZone() *schema.Resource {
 							Required:    true,
 						},
 						"propagation_types": &schema.Schema{
-							Type:        schema.TypeList,
+							Type:        schema.TypeSet,
 							Description: "How to apply the management zone to underlying entities.",
 							Optional:    true,
 							Elem: &schema.Schema{
@@ -56,6 +67,26 @@ func resourceDynatraceManagementZone() *schema.Resource {
 					},
 				},
 			},
+			"entity_selector_rule": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "A list of rules matching entities by an entity selector, for example type(PROCESS_GROUP_INSTANCE),toRelationships.isPgiOfCgi(type(CLOUD_APPLICATION),namespaceName(\"production\")).",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Description: "The rule is enabled (true) or disabled (false).",
+							Optional:    true,
+							Default:     true,
+						},
+						"selector": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The entity selector matching the entities of the management zone. It is checked against the entity selector grammar when planning.",
+							Required:    true,
+						},
+					},
+				},
+			},
 			"dimensional_rule": &schema.Schema{
 				Type:        schema.TypeList,
 				Description: "A list of dimensional data rules for management zone usage. If several rules are specified, the OR logic applies.",
@@ -106,6 +137,50 @@ func resourceDynatraceManagementZone() *schema.Resource {
 			},
 		},
 	}
+
+	// propagation types were a list before version 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		listsToSetsStateUpgrader(0, r.Schema, "rule.propagation_types"),
+		settingsAPIStateUpgrader(1, r.Schema, managementZoneSettings),
+	}
+
+	return r
+}
+
+// managementZoneSettings manages management zones through builtin:management-zones settings objects.
+var managementZoneSettings = &settingsAPIResource{
+	description: "management zone",
+	schemaID:    "builtin:management-zones",
+	nameOf:      settingsValueName,
+	list:        resourceDynatraceManagementZoneNames,
+}
+
+func resourceDynatraceManagementZoneCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	var errs []error
+
+	// rules interpolated from other resources are only checked once they are known
+	rulesKnown := d.NewValueKnown("rule")
+	if rulesKnown {
+		errs = validateRuleConditions(d)
+	}
+
+	errs = append(errs, validateEntitySelectorRules(d)...)
+	if len(errs) != 0 {
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = "  - " + err.Error()
+		}
+
+		return fmt.Errorf("invalid management zone rules:\n%s", strings.Join(messages, "\n"))
+	}
+
+	if !rulesKnown || plansSettingsAPI(d, m) {
+		return nil
+	}
+
+	return validateOnPlan(d, m, resourceDynatraceManagementZone(), "management zone", "/managementZones", func(d *schema.ResourceData) (interface{}, error) {
+		return expandManagementZone(d)
+	})
 }
 
 func resourceDynatraceManagementZoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -116,80 +191,189 @@ func resourceDynatraceManagementZoneCreate(ctx context.Context, d *schema.Resour
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
+	if usesSettingsAPI(d, providerConf) {
+		return resourceDynatraceManagementZoneCreateSettings(ctx, d, m)
+	}
+
 	mz, err := expandManagementZone(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	managementZone, _, err := dynatraceConfigClientV1.ManagementZonesApi.CreateManagementZone(authConfigV1).ManagementZone(*mz).Execute()
+	managementZoneID := ""
+
+	if d.Get("adopt_existing").(bool) {
+		existing, resp, err := dynatraceConfigClientV1.ManagementZonesApi.ListManagementZones(authConfigV1).Execute()
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to get dynatrace management zones", err, resp, nil)...)
+			return diags
+		}
+
+		id, ok, err := findAdoptedEntity("management zone", mz.Name, namedEntitiesFromStubs(existing.Values))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if ok {
+			managementZoneID = id
+		}
+	}
+
+	body, err := json.Marshal(mz)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create dynatrace management zone",
-			Detail:   getErrorMessage(err),
-		})
+		return diag.FromErr(err)
+	}
+
+	if len(managementZoneID) == 0 {
+		if managementZoneID, err = newNumericID(); err != nil {
+			return diag.FromErr(err)
+		}
+		// in the state before the request, an interrupted create leaves the management zone tainted
+		d.SetId(managementZoneID)
+	}
+
+	_, err = restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, "/managementZones/"+managementZoneID, body)
+	if err != nil {
+		if createRejected(err, nil) {
+			d.SetId("")
+		}
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace management zone", err, nil, resourceDynatraceManagementZone().Schema)...)
 		return diags
 	}
 
-	d.SetId(managementZone.Id)
+	d.SetId(managementZoneID)
 
 	resourceDynatraceManagementZoneRead(ctx, d, m)
 
 	return diags
 }
 
+func resourceDynatraceManagementZoneCreateSettings(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	value, err := expandManagementZoneSettings(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("adopt_existing").(bool) {
+		objectID, ok, err := managementZoneSettings.findSettingsObject(providerConf, settingsValueName(value))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if ok {
+			if diags := managementZoneSettings.update(providerConf, objectID, value, resourceDynatraceManagementZone().Schema); diags.HasError() {
+				return diags
+			}
+
+			d.SetId(objectID)
+
+			return resourceDynatraceManagementZoneRead(ctx, d, m)
+		}
+	}
+
+	objectID, diags := managementZoneSettings.create(providerConf, value, resourceDynatraceManagementZone().Schema)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(objectID)
+
+	return resourceDynatraceManagementZoneRead(ctx, d, m)
+}
+
 func resourceDynatraceManagementZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
-	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
-	authConfigV1 := providerConf.AuthConfigV1
 
 	var diags diag.Diagnostics
 
+	if err := managementZoneSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace management zone", err, nil, nil)...)
+		return diags
+	}
+
+	if usesSettingsAPI(d, providerConf) {
+		return resourceDynatraceManagementZoneReadSettings(ctx, d, m)
+	}
+
 	managementZoneID := d.Id()
 
-	managementZone, _, err := dynatraceConfigClientV1.ManagementZonesApi.GetManagementZone(authConfigV1, managementZoneID).Execute()
+	response, err := restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodGet, "/managementZones/"+managementZoneID, nil)
+	if entityNotFound(err, nil) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read dynatrace management zone",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace management zone", err, nil, nil)...)
 		return diags
 	}
 
-	managementZoneRules := flattenManagementZoneRulesData(managementZone.Rules)
-	if err := d.Set("rule", managementZoneRules); err != nil {
+	var managementZone managementZoneConfig
+	if err := json.Unmarshal(response, &managementZone); err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.Set("name", &managementZone.Name)
+	return flattenDynatraceManagementZone(managementZone, d)
+}
 
-	return diags
+func resourceDynatraceManagementZoneReadSettings(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	value, diags := managementZoneSettings.read(providerConf, d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	managementZone, err := flattenManagementZoneSettings(value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return flattenDynatraceManagementZone(*managementZone, d)
 }
 
 func resourceDynatraceManagementZoneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
-	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
-	authConfigV1 := providerConf.AuthConfigV1
 
 	var diags diag.Diagnostics
 
+	if err := managementZoneSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace management zone", err, nil, nil)...)
+		return diags
+	}
+
 	managementZoneID := d.Id()
 
-	if d.HasChange("name") || d.HasChange("rule") {
+	if usesSettingsAPI(d, providerConf) {
+		if d.HasChange("name") || d.HasChange("rule") || d.HasChange("dimensional_rule") || d.HasChange("entity_selector_rule") {
+			value, err := expandManagementZoneSettings(d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if diags := managementZoneSettings.update(providerConf, managementZoneID, value, resourceDynatraceManagementZone().Schema); diags.HasError() {
+				return diags
+			}
+		}
+
+		return resourceDynatraceManagementZoneRead(ctx, d, m)
+	}
+
+	if d.HasChange("name") || d.HasChange("rule") || d.HasChange("dimensional_rule") || d.HasChange("entity_selector_rule") {
 
 		mz, err := expandManagementZone(d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		_, _, err = dynatraceConfigClientV1.ManagementZonesApi.UpdateManagementZone(authConfigV1, managementZoneID).ManagementZone(*mz).Execute()
+		body, err := json.Marshal(mz)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPut, "/managementZones/"+managementZoneID, body)
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update dynatrace management zone",
-				Detail:   getErrorMessage(err),
-			})
+			diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace management zone", err, nil, resourceDynatraceManagementZone().Schema)...)
 			return diags
 		}
 
@@ -205,15 +389,26 @@ func resourceDynatraceManagementZoneDelete(ctx context.Context, d *schema.Resour
 
 	var diags diag.Diagnostics
 
+	if err := managementZoneSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace management zone", err, nil, nil)...)
+		return diags
+	}
+
 	managementZoneID := d.Id()
 
-	_, err := dynatraceConfigClientV1.ManagementZonesApi.DeleteManagementZone(authConfigV1, managementZoneID).Execute()
+	if usesSettingsAPI(d, providerConf) {
+		if diags := managementZoneSettings.delete(providerConf, managementZoneID); diags.HasError() {
+			return diags
+		}
+
+		d.SetId("")
+
+		return diags
+	}
+
+	resp, err := dynatraceConfigClientV1.ManagementZonesApi.DeleteManagementZone(authConfigV1, managementZoneID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete dynatrace management zone",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace management zone", err, resp, nil)...)
 		return diags
 	}
 
@@ -221,3 +416,16 @@ func resourceDynatraceManagementZoneDelete(ctx context.Context, d *schema.Resour
 
 	return diags
 }
+
+func resourceDynatraceManagementZoneNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	stubs, _, err := dynatraceConfigClientV1.ManagementZonesApi.ListManagementZones(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return namedEntitiesFromStubs(stubs.Values), nil
+}
@@ -9,23 +9,24 @@ import (
 )
 
 func resourceDynatraceNotification() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDynatraceNotificationCreate,
 		ReadContext:   resourceDynatraceNotificationRead,
 		UpdateContext: resourceDynatraceNotificationUpdate,
 		DeleteContext: resourceDynatraceNotificationDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: resourceDynatraceNotificationCustomizeDiff,
+		Importer:      importStateByName("notification", resourceDynatraceNotificationNames),
+		SchemaVersion: 2,
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "The name of the notification configuration.",
 				Required:    true,
 			},
+			"api": apiSchema(),
 			"alerting_profile": &schema.Schema{
 				Type:        schema.TypeString,
-				Description: "The ID of the associated alerting profile.",
+				Description: "The ID of the associated alerting profile. IDs of alerting profiles under the API the notification isn't managed through are translated.",
 				Optional:    true,
 				Default:     "c21f969b-5f03-333d-83e0-4f8f136e7682",
 			},
@@ -65,19 +66,19 @@ func resourceDynatraceNotification() *schema.Resource {
 				Optional:    true,
 			},
 			"receivers": &schema.Schema{
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Description: "The list of the email recipients.",
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"cc_receivers": &schema.Schema{
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Description: "The list of the email CC-recipients",
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"bcc_receivers": &schema.Schema{
-				Type:        schema.TypeList,
+				Type:        schema.TypeSet,
 				Description: "The list of the email BCC-recipients",
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
@@ -254,6 +255,32 @@ func resourceDynatraceNotification() *schema.Resource {
 			},
 		},
 	}
+
+	// receivers were lists before version 1, IDs were config v1 IDs before version 2
+	r.StateUpgraders = []schema.StateUpgrader{
+		listsToSetsStateUpgrader(0, r.Schema, "receivers", "cc_receivers", "bcc_receivers"),
+		settingsAPIStateUpgrader(1, r.Schema, notificationSettings),
+	}
+
+	return r
+}
+
+// notificationSettings manages notifications through builtin:problem.notifications settings objects.
+var notificationSettings = &settingsAPIResource{
+	description: "notification",
+	schemaID:    "builtin:problem.notifications",
+	nameOf:      notificationSettingsName,
+	list:        resourceDynatraceNotificationNames,
+}
+
+func resourceDynatraceNotificationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if plansSettingsAPI(d, m) {
+		return nil
+	}
+
+	return validateOnPlan(d, m, resourceDynatraceNotification(), "notification", "/notifications", func(d *schema.ResourceData) (interface{}, error) {
+		return expandDynatraceNotification(d)
+	})
 }
 
 func resourceDynatraceNotificationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -264,18 +291,38 @@ func resourceDynatraceNotificationCreate(ctx context.Context, d *schema.Resource
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
+	if usesSettingsAPI(d, providerConf) {
+		value, err := expandNotificationSettings(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if value["alertingProfile"], err = notificationAlertingProfileID(ctx, m, d.Get("alerting_profile").(string), true); err != nil {
+			return diag.FromErr(err)
+		}
+
+		notificationID, diags := notificationSettings.create(providerConf, value, resourceDynatraceNotification().Schema)
+		if diags.HasError() {
+			return diags
+		}
+
+		d.SetId(notificationID)
+
+		return resourceDynatraceNotificationRead(ctx, d, m)
+	}
+
 	dn, err := expandDynatraceNotification(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	_, err = dynatraceConfigClientV1.NotificationsApi.CreateNotificationConfig(authConfigV1).NotificationConfig(*dn).Execute()
+	if dn.AlertingProfile, err = notificationAlertingProfileID(ctx, m, dn.AlertingProfile, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := dynatraceConfigClientV1.NotificationsApi.CreateNotificationConfig(authConfigV1).NotificationConfig(*dn).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create notification",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create notification", err, resp, resourceDynatraceNotification().Schema)...)
 		return diags
 	}
 
@@ -283,13 +330,9 @@ func resourceDynatraceNotificationCreate(ctx context.Context, d *schema.Resource
 	name, nameOk := d.GetOk("name")
 	dnType, dnTypeOk := d.GetOk("type")
 
-	notification, _, err := dynatraceConfigClientV1.NotificationsApi.ListNotificationConfigs(authConfigV1).Execute()
+	notification, resp, err := dynatraceConfigClientV1.NotificationsApi.ListNotificationConfigs(authConfigV1).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to get dynatrace notifications",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to get dynatrace notifications", err, resp, nil)...)
 		return diags
 	}
 
@@ -319,19 +362,39 @@ func resourceDynatraceNotificationRead(ctx context.Context, d *schema.ResourceDa
 
 	var diags diag.Diagnostics
 
+	if err := notificationSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace notification", err, nil, nil)...)
+		return diags
+	}
+
 	notificationID := d.Id()
+	priorAlertingProfile := d.Get("alerting_profile").(string)
+
+	if usesSettingsAPI(d, providerConf) {
+		value, diags := notificationSettings.read(providerConf, notificationID)
+		if diags.HasError() {
+			return diags
+		}
+
+		notification, err := flattenNotificationSettings(value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		flattenDynatraceNotification(*notification, d)
+		keepNotificationAlertingProfile(ctx, d, m, priorAlertingProfile, true)
+
+		return diags
+	}
 
-	notification, _, err := dynatraceConfigClientV1.NotificationsApi.GetNotificationConfig(authConfigV1, notificationID).Execute()
+	notification, resp, err := dynatraceConfigClientV1.NotificationsApi.GetNotificationConfig(authConfigV1, notificationID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read dynatrace notification",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace notification", err, resp, nil)...)
 		return diags
 	}
 
 	flattenDynatraceNotification(notification, d)
+	keepNotificationAlertingProfile(ctx, d, m, priorAlertingProfile, false)
 
 	return diags
 }
@@ -343,20 +406,42 @@ func resourceDynatraceNotificationUpdate(ctx context.Context, d *schema.Resource
 
 	var diags diag.Diagnostics
 
+	if err := notificationSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace notification", err, nil, nil)...)
+		return diags
+	}
+
 	notificationID := d.Id()
 
+	if usesSettingsAPI(d, providerConf) {
+		value, err := expandNotificationSettings(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if value["alertingProfile"], err = notificationAlertingProfileID(ctx, m, d.Get("alerting_profile").(string), true); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if diags := notificationSettings.update(providerConf, notificationID, value, resourceDynatraceNotification().Schema); diags.HasError() {
+			return diags
+		}
+
+		return resourceDynatraceNotificationRead(ctx, d, m)
+	}
+
 	dn, err := expandDynatraceNotification(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	_, _, err = dynatraceConfigClientV1.NotificationsApi.UpdateNotificationConfig(authConfigV1, notificationID).NotificationConfig(*dn).Execute()
+	if dn.AlertingProfile, err = notificationAlertingProfileID(ctx, m, dn.AlertingProfile, false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, resp, err := dynatraceConfigClientV1.NotificationsApi.UpdateNotificationConfig(authConfigV1, notificationID).NotificationConfig(*dn).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update dynatrace notification",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace notification", err, resp, resourceDynatraceNotification().Schema)...)
 		return diags
 	}
 
@@ -371,15 +456,26 @@ func resourceDynatraceNotificationDelete(ctx context.Context, d *schema.Resource
 
 	var diags diag.Diagnostics
 
+	if err := notificationSettings.migrateID(ctx, d, m); err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete notification", err, nil, nil)...)
+		return diags
+	}
+
 	notificationID := d.Id()
 
-	_, err := dynatraceConfigClientV1.NotificationsApi.DeleteNotificationConfig(authConfigV1, notificationID).Execute()
+	if usesSettingsAPI(d, providerConf) {
+		if diags := notificationSettings.delete(providerConf, notificationID); diags.HasError() {
+			return diags
+		}
+
+		d.SetId("")
+
+		return diags
+	}
+
+	resp, err := dynatraceConfigClientV1.NotificationsApi.DeleteNotificationConfig(authConfigV1, notificationID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete dynatrace notification",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace notification", err, resp, nil)...)
 		return diags
 	}
 
@@ -388,3 +484,56 @@ func resourceDynatraceNotificationDelete(ctx context.Context, d *schema.Resource
 	return diags
 
 }
+
+// resourceDynatraceNotificationNames lists notifications both as "<name>" and "<type>/<name>",
+// since names are only unique per type.
+func resourceDynatraceNotificationNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	notifications, _, err := dynatraceConfigClientV1.NotificationsApi.ListNotificationConfigs(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	entities := []namedEntity{}
+	for _, notification := range notifications.GetValues() {
+		if notification.Name == nil {
+			continue
+		}
+		entities = append(entities, namedEntity{id: notification.Id, name: *notification.Name})
+		if notification.Type != nil {
+			entities = append(entities, namedEntity{id: notification.Id, name: *notification.Type + "/" + *notification.Name})
+		}
+	}
+
+	return entities, nil
+}
+
+// notificationAlertingProfileID returns the ID of an alerting profile under the API
+// the notification is managed through, for alerting profiles referred to by their
+// ID under the other API.
+func notificationAlertingProfileID(ctx context.Context, m interface{}, alertingProfileID string, settingsAPI bool) (string, error) {
+	if len(alertingProfileID) == 0 || isSettingsObjectID(alertingProfileID) == settingsAPI {
+		return alertingProfileID, nil
+	}
+
+	if settingsAPI {
+		return alertingProfileSettings.settingsObjectIDOf(ctx, m, alertingProfileID)
+	}
+	return alertingProfileSettings.configIDOf(ctx, m, alertingProfileID)
+}
+
+// keepNotificationAlertingProfile keeps an alerting_profile referring to the read
+// alerting profile by its ID under the other API, so that the reference doesn't
+// show as changed.
+func keepNotificationAlertingProfile(ctx context.Context, d *schema.ResourceData, m interface{}, prior string, settingsAPI bool) {
+	if len(prior) == 0 || prior == d.Get("alerting_profile").(string) {
+		return
+	}
+
+	if id, err := notificationAlertingProfileID(ctx, m, prior, settingsAPI); err == nil && id == d.Get("alerting_profile").(string) {
+		d.Set("alerting_profile", prior)
+	}
+}
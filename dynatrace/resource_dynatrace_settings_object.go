@@ -0,0 +1,217 @@
+package dynatrace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	dynatraceEnvironmentV2 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/environment/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDynatraceSettingsObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDynatraceSettingsObjectCreate,
+		ReadContext:   resourceDynatraceSettingsObjectRead,
+		UpdateContext: resourceDynatraceSettingsObjectUpdate,
+		DeleteContext: resourceDynatraceSettingsObjectDelete,
+		CustomizeDiff: resourceDynatraceSettingsObjectCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"schema_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The schema on which the object is based, for example builtin:alerting.profile.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"schema_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The version of the schema on which the object is based. The latest version is used if not set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"scope": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The scope that the object targets, for example environment or the ID of a host.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"value": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "The value of the setting as JSON. Properties that aren't configured get the defaults of the schema and aren't compared. The value is checked against the schema during plan.",
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				StateFunc:    normalizeJSONState,
+			},
+			"insert_after": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "The ID of the object the new object is inserted after, for schemas with ordered objects. The object is appended if not set. Changing it recreates the object.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"summary": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "A short summary of the settings.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceDynatraceSettingsObjectCustomizeDiff checks the scope and the value of
+// a new or changed object against its schema, so mistakes show up in the plan.
+func resourceDynatraceSettingsObjectCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	providerConf, ok := m.(*ProviderConfiguration)
+	if !ok {
+		return nil
+	}
+
+	changed := len(d.Id()) == 0
+	for _, k := range []string{"schema_id", "scope", "value"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+		changed = changed || d.HasChange(k)
+	}
+
+	// schema_version is unknown until created when it isn't configured, the object
+	// is checked against the latest version of the schema then
+	schemaVersion := ""
+	if d.NewValueKnown("schema_version") {
+		schemaVersion = d.Get("schema_version").(string)
+		changed = changed || d.HasChange("schema_version")
+	}
+	if !changed {
+		return nil
+	}
+
+	schemaID := d.Get("schema_id").(string)
+
+	s, err := fetchSettingsSchema(providerConf, schemaID, schemaVersion)
+	if err != nil {
+		if restErr, ok := err.(RESTError); ok && restErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("schema_id: %s is not a schema of the environment", schemaID)
+		}
+		return fmt.Errorf("unable to get the schema %s: %s", schemaID, getErrorMessage(err))
+	}
+
+	value, err := decodeSettingsValue(d.Get("value").(string))
+	if err != nil {
+		return err
+	}
+
+	errs := validateSettingsValue(s, value)
+	if err := validateSettingsScope(s, d.Get("scope").(string)); err != nil {
+		errs = append([]error{err}, errs...)
+	}
+
+	if len(errs) != 0 {
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = "  - " + err.Error()
+		}
+
+		return fmt.Errorf("invalid settings object:\n%s", strings.Join(messages, "\n"))
+	}
+
+	return nil
+}
+
+func resourceDynatraceSettingsObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceEnvironmentClientV2 := providerConf.DynatraceEnvironmentClientV2
+	authEnvironmentV2 := providerConf.AuthEnvironmentV2
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	so, err := expandSettingsObjectCreate(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	settingsObjects, resp, err := dynatraceEnvironmentClientV2.SettingsObjectsApi.PostSettingsObjects(authEnvironmentV2).SettingsObjectCreate([]dynatraceEnvironmentV2.SettingsObjectCreate{*so}).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to create settings object", err, resp, resourceDynatraceSettingsObject().Schema)...)
+		return diags
+	}
+
+	if len(settingsObjects) != 1 || settingsObjects[0].ObjectId == nil {
+		return diag.FromErr(fmt.Errorf("the response to creating a %s settings object holds no object ID", so.SchemaId))
+	}
+
+	d.SetId(*settingsObjects[0].ObjectId)
+
+	return resourceDynatraceSettingsObjectRead(ctx, d, m)
+}
+
+func resourceDynatraceSettingsObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	objectID := d.Id()
+
+	settingsObject, err := getSettingsObject(providerConf, objectID)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read settings object", err, nil, nil)...)
+		return diags
+	}
+
+	return flattenSettingsObject(settingsObject, d)
+}
+
+func resourceDynatraceSettingsObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceEnvironmentClientV2 := providerConf.DynatraceEnvironmentClientV2
+	authEnvironmentV2 := providerConf.AuthEnvironmentV2
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	objectID := d.Id()
+
+	if d.HasChange("value") || d.HasChange("schema_version") {
+
+		so, err := expandSettingsObjectUpdate(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, resp, err := dynatraceEnvironmentClientV2.SettingsObjectsApi.PutSettingsObjectByObjectId(authEnvironmentV2, objectID).SettingsObjectUpdate(*so).Execute()
+		if err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to update settings object", err, resp, resourceDynatraceSettingsObject().Schema)...)
+			return diags
+		}
+	}
+
+	return resourceDynatraceSettingsObjectRead(ctx, d, m)
+}
+
+func resourceDynatraceSettingsObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceEnvironmentClientV2 := providerConf.DynatraceEnvironmentClientV2
+	authEnvironmentV2 := providerConf.AuthEnvironmentV2
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	objectID := d.Id()
+
+	resp, err := dynatraceEnvironmentClientV2.SettingsObjectsApi.DeleteSettingsObjectByObjectId(authEnvironmentV2, objectID).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete settings object", err, resp, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,147 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDynatraceSettingsObject_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	name := fmt.Sprintf("%s", rName)
+	resourceName := "dynatrace_settings_object.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDynatraceSettingsObjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDynatraceSettingsObjectConfig(name, "ERROR"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceSettingsObjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "schema_id", "builtin:alerting.profile"),
+					resource.TestCheckResourceAttrSet(resourceName, "schema_version"),
+				),
+			},
+			{
+				Config: testAccDynatraceSettingsObjectConfig(name, "AVAILABILITY"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDynatraceSettingsObjectExists(resourceName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDynatraceSettingsObjectDestroy(s *terraform.State) error {
+	providerConf := testAccProvider.Meta().(*ProviderConfiguration)
+	dynatraceEnvironmentClientV2 := providerConf.DynatraceEnvironmentClientV2
+	authEnvironmentV2 := providerConf.AuthEnvironmentV2
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "dynatrace_settings_object" {
+			continue
+		}
+
+		objectID := rs.Primary.ID
+
+		if _, _, err := dynatraceEnvironmentClientV2.SettingsObjectsApi.GetSettingsObjectByObjectId(authEnvironmentV2, objectID).Execute(); err == nil {
+			return fmt.Errorf("Settings object still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckDynatraceSettingsObjectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		providerConf := testAccProvider.Meta().(*ProviderConfiguration)
+		dynatraceEnvironmentClientV2 := providerConf.DynatraceEnvironmentClientV2
+		authEnvironmentV2 := providerConf.AuthEnvironmentV2
+
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		if _, _, err := dynatraceEnvironmentClientV2.SettingsObjectsApi.GetSettingsObjectByObjectId(authEnvironmentV2, rs.Primary.ID).Execute(); err != nil {
+			return fmt.Errorf("Settings object does not exist: %s", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccDynatraceSettingsObjectConfig(name string, severityLevel string) string {
+	return fmt.Sprintf(`resource "dynatrace_settings_object" "test" {
+		schema_id = "builtin:alerting.profile"
+		scope     = "environment"
+		value = jsonencode({
+		  name = "%s"
+		  severityRules = [
+			{
+			  severityLevel        = "%s"
+			  delayInMinutes       = 0
+			  tagFilterIncludeMode = "NONE"
+			}
+		  ]
+		  eventFilters = []
+		})
+	  }
+`, name, severityLevel)
+}
+
+func TestResourceDynatraceSettingsObjectCustomizeDiff(t *testing.T) {
+	var s settingsSchema
+	if err := json.Unmarshal([]byte(testSettingsSchema), &s); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// the latest version of the schema, as requested without a schema_version
+	providerConf := &ProviderConfiguration{settingsSchemas: map[string]*settingsSchema{"builtin:alerting.profile@": &s}}
+
+	cases := []struct {
+		Name          string
+		Value         string
+		ExpectedError string
+	}{
+		{
+			"valid",
+			`{"name":"Ops","managementZone":null,"severityRules":[]}`,
+			"",
+		},
+		{
+			"invalid",
+			`{"name":"Ops","managementZone":null,"severityRules":[],"severity":"ERROR"}`,
+			"value.severity: is not a property of builtin:alerting.profile",
+		},
+	}
+
+	for _, tc := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"schema_id": "builtin:alerting.profile",
+			"scope":     "environment",
+			"value":     tc.Value,
+		})
+
+		_, err := resourceDynatraceSettingsObject().Diff(context.Background(), nil, config, providerConf)
+		if len(tc.ExpectedError) == 0 && err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+		if len(tc.ExpectedError) != 0 && (err == nil || !strings.Contains(err.Error(), tc.ExpectedError)) {
+			t.Fatalf("%s: unexpected error.\nExpected: %s\nGiven:    %v", tc.Name, tc.ExpectedError, err)
+		}
+	}
+}
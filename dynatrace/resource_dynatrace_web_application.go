@@ -13,9 +13,8 @@ func resourceDynatraceWebApplication() *schema.Resource {
 		ReadContext:   resourceDynatraceWebApplicationRead,
 		UpdateContext: resourceDynatraceWebApplicationUpdate,
 		DeleteContext: resourceDynatraceWebApplicationDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		CustomizeDiff: resourceDynatraceWebApplicationCustomizeDiff,
+		Importer:      importStateByName("web application", resourceDynatraceWebApplicationNames),
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
@@ -1107,6 +1106,12 @@ func userActionNamingRuleSchema() *schema.Resource {
 	}
 }
 
+func resourceDynatraceWebApplicationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateOnPlan(d, m, resourceDynatraceWebApplication(), "web application", "/applications/web", func(d *schema.ResourceData) (interface{}, error) {
+		return expandWebApplication(d)
+	})
+}
+
 func resourceDynatraceWebApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	providerConf := m.(*ProviderConfiguration)
 	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
@@ -1119,13 +1124,9 @@ func resourceDynatraceWebApplicationCreate(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	webApplication, _, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.CreateWebApplicationConfig(authConfigV1).WebApplicationConfig(*wa).Execute()
+	webApplication, resp, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.CreateWebApplicationConfig(authConfigV1).WebApplicationConfig(*wa).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create dynatrace web app",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace web app", err, resp, resourceDynatraceWebApplication().Schema)...)
 		return diags
 	}
 
@@ -1146,13 +1147,9 @@ func resourceDynatraceWebApplicationRead(ctx context.Context, d *schema.Resource
 
 	webApplicationID := d.Id()
 
-	webApplication, _, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.GetWebApplicationConfig(authConfigV1, webApplicationID).Execute()
+	webApplication, resp, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.GetWebApplicationConfig(authConfigV1, webApplicationID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to read dynatrace web app",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace web app", err, resp, nil)...)
 		return diags
 	}
 
@@ -1176,13 +1173,9 @@ func resourceDynatraceWebApplicationUpdate(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	_, _, err = dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.UpdateWebApplicationConfig(authConfigV1, webApplicationID).WebApplicationConfig(*wa).Execute()
+	_, resp, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.UpdateWebApplicationConfig(authConfigV1, webApplicationID).WebApplicationConfig(*wa).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update dynatrace web app",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace web app", err, resp, resourceDynatraceWebApplication().Schema)...)
 		return diags
 	}
 
@@ -1199,13 +1192,9 @@ func resourceDynatraceWebApplicationDelete(ctx context.Context, d *schema.Resour
 
 	webApplicationID := d.Id()
 
-	_, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.DeleteWebApplicationConfig(authConfigV1, webApplicationID).Execute()
+	resp, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.DeleteWebApplicationConfig(authConfigV1, webApplicationID).Execute()
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to delete dynatrace web app",
-			Detail:   getErrorMessage(err),
-		})
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace web app", err, resp, nil)...)
 		return diags
 	}
 
@@ -1213,3 +1202,16 @@ func resourceDynatraceWebApplicationDelete(ctx context.Context, d *schema.Resour
 
 	return diags
 }
+
+func resourceDynatraceWebApplicationNames(ctx context.Context, m interface{}) ([]namedEntity, error) {
+	providerConf := m.(*ProviderConfiguration)
+	dynatraceConfigClientV1 := providerConf.DynatraceConfigClientV1
+	authConfigV1 := providerConf.AuthConfigV1
+
+	stubs, _, err := dynatraceConfigClientV1.RUMWebApplicationConfigurationApi.ListWebApplicationConfigs(authConfigV1).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return namedEntitiesFromStubs(stubs.Values), nil
+}
@@ -0,0 +1,140 @@
+package dynatrace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	dynatraceEnvironmentV2 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/environment/dynatrace"
+)
+
+// RESTError is returned by restRequest for responses with a status code of 300 or above.
+type RESTError struct {
+	Method     string
+	URL        string
+	Status     string
+	StatusCode int
+	body       []byte
+}
+
+func (e RESTError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status)
+}
+
+// Body returns the raw bytes of the response
+func (e RESTError) Body() []byte {
+	return e.body
+}
+
+// restRequest sends a request with a raw JSON body, for payloads the generated
+// clients can't represent without losing fields or responses they can't decode.
+// baseURL is the base URL of the API, like the configV1URL of the provider
+// configuration, and auth the context its generated client is called with. The
+// path is relative to the base URL, for example /dashboards/{id}.
+func restRequest(auth context.Context, baseURL string, method string, path string, body []byte) ([]byte, error) {
+	var reqBody *bytes.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	} else {
+		reqBody = bytes.NewReader([]byte{})
+	}
+
+	req, err := http.NewRequestWithContext(auth, method, baseURL+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	if authorization := apiTokenAuthorization(auth); len(authorization) != 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		return respBody, RESTError{
+			Method:     req.Method,
+			URL:        req.URL.String(),
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			body:       respBody,
+		}
+	}
+
+	return respBody, nil
+}
+
+// apiTokenAuthorization returns the Authorization header for the Api-Token carried
+// by the context a generated client is called with. The clients of the config v1
+// and the environment v2 API carry it under keys of their own.
+func apiTokenAuthorization(auth context.Context) string {
+	var apiKey struct{ Key, Prefix string }
+	if keys, ok := auth.Value(dynatraceConfigV1.ContextAPIKeys).(map[string]dynatraceConfigV1.APIKey); ok {
+		apiKey.Key, apiKey.Prefix = keys["Api-Token"].Key, keys["Api-Token"].Prefix
+	} else if keys, ok := auth.Value(dynatraceEnvironmentV2.ContextAPIKeys).(map[string]dynatraceEnvironmentV2.APIKey); ok {
+		apiKey.Key, apiKey.Prefix = keys["Api-Token"].Key, keys["Api-Token"].Prefix
+	}
+
+	if len(apiKey.Key) == 0 || len(apiKey.Prefix) == 0 {
+		return apiKey.Key
+	}
+	return apiKey.Prefix + " " + apiKey.Key
+}
+
+// listPages requests the pages of a list one after another, listPage gets the key
+// of the page to request, empty for the first one, and returns the key of the
+// next page, nil after the last one. The next page key encodes the query, no
+// other parameters may be passed along with it.
+func listPages(listPage func(nextPageKey string) (*string, error)) error {
+	nextPageKey := ""
+	for {
+		next, err := listPage(nextPageKey)
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			return nil
+		}
+		nextPageKey = *next
+	}
+}
+
+// configV1ErrorResponse is the body of an error response of the config API.
+type configV1ErrorResponse struct {
+	Error configV1Error `json:"error"`
+}
+
+type configV1Error struct {
+	Code                 int                           `json:"code"`
+	Message              string                        `json:"message"`
+	ConstraintViolations []configV1ConstraintViolation `json:"constraintViolations"`
+}
+
+// configV1ConstraintViolation is a field of a request the API rejected. The path
+// is the JSON path of the field, for example rules[0].conditions[1].key.
+type configV1ConstraintViolation struct {
+	Path              string `json:"path"`
+	Message           string `json:"message"`
+	ParameterLocation string `json:"parameterLocation"`
+	Location          string `json:"location"`
+}
+
+// APIError decodes the body of the response as an error of the config API.
+func (e RESTError) APIError() (*configV1Error, bool) {
+	return decodeAPIErrorBody(e.body)
+}
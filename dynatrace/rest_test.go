@@ -0,0 +1,97 @@
+package dynatrace
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	dynatraceEnvironmentV2 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/environment/dynatrace"
+)
+
+func TestListPages(t *testing.T) {
+	page2, page3 := "page-2", "page-3"
+	pages := map[string]*string{
+		"":       &page2,
+		"page-2": &page3,
+		"page-3": nil,
+	}
+
+	cases := []struct {
+		Name           string
+		FailOn         string
+		ExpectedOutput []string
+		ExpectedError  string
+	}{
+		{
+			"all pages",
+			"",
+			[]string{"", "page-2", "page-3"},
+			"",
+		},
+		{
+			"error",
+			"page-2",
+			[]string{"", "page-2"},
+			"page-2 failed",
+		},
+	}
+
+	for _, tc := range cases {
+		requested := []string{}
+		err := listPages(func(nextPageKey string) (*string, error) {
+			requested = append(requested, nextPageKey)
+			if len(tc.FailOn) != 0 && nextPageKey == tc.FailOn {
+				return nil, errors.New(nextPageKey + " failed")
+			}
+			return pages[nextPageKey], nil
+		})
+
+		message := ""
+		if err != nil {
+			message = err.Error()
+		}
+
+		if message != tc.ExpectedError {
+			t.Fatalf("%s: unexpected error.\nExpected: %q\nGiven:    %q", tc.Name, tc.ExpectedError, message)
+		}
+		if !reflect.DeepEqual(requested, tc.ExpectedOutput) {
+			t.Fatalf("%s: unexpected pages.\nExpected: %v\nGiven:    %v", tc.Name, tc.ExpectedOutput, requested)
+		}
+	}
+}
+
+func TestAPITokenAuthorization(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          context.Context
+		ExpectedOutput string
+	}{
+		{
+			"config v1",
+			context.WithValue(context.Background(), dynatraceConfigV1.ContextAPIKeys, map[string]dynatraceConfigV1.APIKey{
+				"Api-Token": {Key: "dt0c01.config", Prefix: "Api-Token"},
+			}),
+			"Api-Token dt0c01.config",
+		},
+		{
+			"environment v2",
+			context.WithValue(context.Background(), dynatraceEnvironmentV2.ContextAPIKeys, map[string]dynatraceEnvironmentV2.APIKey{
+				"Api-Token": {Key: "dt0c01.environment", Prefix: "Api-Token"},
+			}),
+			"Api-Token dt0c01.environment",
+		},
+		{
+			"no token",
+			context.Background(),
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		if output := apiTokenAuthorization(tc.Input); output != tc.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %q\nGiven:    %q", tc.Name, tc.ExpectedOutput, output)
+		}
+	}
+}
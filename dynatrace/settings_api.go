@@ -0,0 +1,322 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	dynatraceEnvironmentV2 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/environment/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// configV1IDPattern matches the IDs of the config v1 API, numbers for management
+// zones and UUIDs for everything else. Settings 2.0 object IDs are base64 strings.
+var configV1IDPattern = regexp.MustCompile(`^(-?[0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// settingsAPIResource describes a resource that can be managed through the config
+// v1 API or through objects of a Settings 2.0 schema. Both APIs store the same
+// entities under different IDs.
+type settingsAPIResource struct {
+	description string
+	schemaID    string
+	// the name of an entity, as held by the value of its settings object
+	nameOf func(value map[string]interface{}) string
+	// lists the entities of the config v1 API
+	list func(ctx context.Context, m interface{}) ([]namedEntity, error)
+}
+
+// apiSchema returns the api argument of resources described by settingsAPIResource.
+func apiSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The API the resource is managed through, config for the config v1 API or settings for the Settings 2.0 API. Defaults to the default_api of the provider. Switching the API keeps the entity, its ID is replaced by the ID under the other API.",
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{"config", "settings"}, false),
+	}
+}
+
+// usesSettingsAPI reports whether a resource is managed through the Settings 2.0 API.
+func usesSettingsAPI(d *schema.ResourceData, providerConf *ProviderConfiguration) bool {
+	return effectiveAPI(d.Get("api").(string), providerConf) == "settings"
+}
+
+// plansSettingsAPI is usesSettingsAPI for plans. Providers that aren't configured
+// yet, as during validation, use the config v1 API.
+func plansSettingsAPI(d *schema.ResourceDiff, m interface{}) bool {
+	providerConf, _ := m.(*ProviderConfiguration)
+	return effectiveAPI(d.Get("api").(string), providerConf) == "settings"
+}
+
+func effectiveAPI(api string, providerConf *ProviderConfiguration) string {
+	if len(api) != 0 {
+		return api
+	}
+	if providerConf != nil && len(providerConf.DefaultAPI) != 0 {
+		return providerConf.DefaultAPI
+	}
+	return "config"
+}
+
+func isSettingsObjectID(id string) bool {
+	return len(id) != 0 && !configV1IDPattern.MatchString(id)
+}
+
+// migrateID replaces the ID of the resource by the ID of the same entity under the
+// API the resource is managed through, if the ID is one of the other API. This
+// happens when the api of the resource or the default_api of the provider changes,
+// and on imports by the ID of the other API. Entities are matched by name.
+func (r *settingsAPIResource) migrateID(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	id, err := r.migratedID(ctx, m, d.Get("api").(string), d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return nil
+}
+
+// migratedID returns the ID of the entity with the ID under the API a resource
+// with the api argument is managed through.
+func (r *settingsAPIResource) migratedID(ctx context.Context, m interface{}, api string, id string) (string, error) {
+	settingsAPI := effectiveAPI(api, m.(*ProviderConfiguration)) == "settings"
+	if settingsAPI == isSettingsObjectID(id) {
+		return id, nil
+	}
+
+	if settingsAPI {
+		return r.settingsObjectIDOf(ctx, m, id)
+	}
+	return r.configIDOf(ctx, m, id)
+}
+
+// settingsObjectIDOf returns the ID of the settings object holding the entity with
+// the config v1 ID.
+func (r *settingsAPIResource) settingsObjectIDOf(ctx context.Context, m interface{}, configID string) (string, error) {
+	entities, err := r.list(ctx, m)
+	if err != nil {
+		return "", err
+	}
+
+	names := []string{}
+	for _, e := range entities {
+		if e.id == configID {
+			names = append(names, e.name)
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("%s %s doesn't exist in the config API, unable to move it to the Settings 2.0 API", r.description, configID)
+	}
+
+	id, ok, err := r.findSettingsObject(m.(*ProviderConfiguration), names...)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("no %s settings object is named %q, unable to move %s %s to the Settings 2.0 API", r.schemaID, names[0], r.description, configID)
+	}
+
+	return id, nil
+}
+
+// configIDOf returns the config v1 ID of the entity held by the settings object.
+func (r *settingsAPIResource) configIDOf(ctx context.Context, m interface{}, objectID string) (string, error) {
+	value, diags := r.read(m.(*ProviderConfiguration), objectID)
+	if diags.HasError() {
+		return "", diagnosticsError(diags)
+	}
+	name := r.nameOf(value)
+
+	entities, err := r.list(ctx, m)
+	if err != nil {
+		return "", err
+	}
+
+	for _, e := range entities {
+		if e.name == name {
+			return e.id, nil
+		}
+	}
+	return "", fmt.Errorf("no %s is named %q in the config API, unable to move settings object %s to it", r.description, name, objectID)
+}
+
+// findSettingsObject returns the ID of the settings object of the schema with one
+// of the names. Several objects with the names are an error.
+func (r *settingsAPIResource) findSettingsObject(providerConf *ProviderConfiguration, names ...string) (string, bool, error) {
+	objectIDs, err := r.settingsObjectsNamed(providerConf, names...)
+	if err != nil {
+		return "", false, err
+	}
+
+	switch len(objectIDs) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return objectIDs[0], true, nil
+	default:
+		sort.Strings(objectIDs)
+		return "", false, fmt.Errorf("%d %s settings objects are named %q: %s", len(objectIDs), r.schemaID, names[0], strings.Join(objectIDs, ", "))
+	}
+}
+
+// settingsObjectsNamed returns the IDs of the settings objects of the schema with one of the names.
+func (r *settingsAPIResource) settingsObjectsNamed(providerConf *ProviderConfiguration, names ...string) ([]string, error) {
+	settingsObjects, diags := findSettingsObjects(providerConf, &settingsObjectsFilter{schemaIDs: []string{r.schemaID}, scopes: []string{"environment"}})
+	if diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+
+	objectIDs := []string{}
+	for _, so := range settingsObjects {
+		value, err := decodeSettingsValue(string(so.Value))
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			if r.nameOf(value) == name {
+				objectIDs = append(objectIDs, so.ObjectID)
+				break
+			}
+		}
+	}
+
+	return objectIDs, nil
+}
+
+// create stores a new settings object of the schema in the environment scope. The
+// API picks the ID of the object, if the response to the request is lost the new
+// object is told apart from existing ones with the same name.
+func (r *settingsAPIResource) create(providerConf *ProviderConfiguration, value map[string]interface{}, s map[string]*schema.Schema) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	existing, err := r.settingsObjectsNamed(providerConf, r.nameOf(value))
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace "+r.description, err, nil, nil)...)
+		return "", diags
+	}
+
+	so := dynatraceEnvironmentV2.NewSettingsObjectCreate("environment", r.schemaID, value)
+
+	settingsObjects, resp, err := providerConf.DynatraceEnvironmentClientV2.SettingsObjectsApi.PostSettingsObjects(providerConf.AuthEnvironmentV2).SettingsObjectCreate([]dynatraceEnvironmentV2.SettingsObjectCreate{*so}).Execute()
+	if err != nil {
+		if !createRejected(err, resp) {
+			if objectID, ok := r.createdSettingsObject(providerConf, r.nameOf(value), existing); ok {
+				return objectID, diags
+			}
+		}
+		diags = append(diags, apiErrorDiagnostics("Unable to create dynatrace "+r.description, err, resp, s)...)
+		return "", diags
+	}
+
+	if len(settingsObjects) != 1 || settingsObjects[0].ObjectId == nil {
+		return "", diag.FromErr(fmt.Errorf("the response to creating a %s settings object holds no object ID", r.schemaID))
+	}
+
+	return *settingsObjects[0].ObjectId, diags
+}
+
+// createdSettingsObject returns the ID of the one settings object with the name
+// that isn't among the existing ones, the object created by a request that failed
+// without a response.
+func (r *settingsAPIResource) createdSettingsObject(providerConf *ProviderConfiguration, name string, existing []string) (string, bool) {
+	objectIDs, err := r.settingsObjectsNamed(providerConf, name)
+	if err != nil {
+		return "", false
+	}
+
+	created := newSettingsObjectIDs(existing, objectIDs)
+	if len(created) != 1 {
+		return "", false
+	}
+
+	return created[0], true
+}
+
+// newSettingsObjectIDs returns the IDs of after that aren't in before.
+func newSettingsObjectIDs(before []string, after []string) []string {
+	known := map[string]bool{}
+	for _, id := range before {
+		known[id] = true
+	}
+
+	added := []string{}
+	for _, id := range after {
+		if !known[id] {
+			added = append(added, id)
+		}
+	}
+
+	return added
+}
+
+func (r *settingsAPIResource) read(providerConf *ProviderConfiguration, objectID string) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	settingsObject, err := getSettingsObject(providerConf, objectID)
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to read dynatrace "+r.description, err, nil, nil)...)
+		return nil, diags
+	}
+
+	if len(settingsObject.Value) == 0 || string(settingsObject.Value) == "null" {
+		return map[string]interface{}{}, diags
+	}
+
+	value, err := decodeSettingsValue(string(settingsObject.Value))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return value, diags
+}
+
+func (r *settingsAPIResource) update(providerConf *ProviderConfiguration, objectID string, value map[string]interface{}, s map[string]*schema.Schema) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	so := dynatraceEnvironmentV2.NewSettingsObjectUpdate(value)
+
+	_, resp, err := providerConf.DynatraceEnvironmentClientV2.SettingsObjectsApi.PutSettingsObjectByObjectId(providerConf.AuthEnvironmentV2, objectID).SettingsObjectUpdate(*so).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to update dynatrace "+r.description, err, resp, s)...)
+	}
+
+	return diags
+}
+
+func (r *settingsAPIResource) delete(providerConf *ProviderConfiguration, objectID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	resp, err := providerConf.DynatraceEnvironmentClientV2.SettingsObjectsApi.DeleteSettingsObjectByObjectId(providerConf.AuthEnvironmentV2, objectID).Execute()
+	if err != nil {
+		diags = append(diags, apiErrorDiagnostics("Unable to delete dynatrace "+r.description, err, resp, nil)...)
+	}
+
+	return diags
+}
+
+// settingsInt returns an integer of a settings value, which is a json.Number when
+// read from the API and an int when expanded from the configuration.
+func settingsInt(v interface{}) int64 {
+	switch n := v.(type) {
+	case json.Number:
+		i, _ := n.Int64()
+		return i
+	case int:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	}
+	return 0
+}
+
+// settingsValueName returns the name property of a settings value.
+func settingsValueName(value map[string]interface{}) string {
+	name, _ := value["name"].(string)
+	return name
+}
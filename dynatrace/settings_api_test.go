@@ -0,0 +1,44 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsSettingsObjectID(t *testing.T) {
+	cases := []struct {
+		Input          string
+		ExpectedOutput bool
+	}{
+		{"", false},
+		{"-6239538939987181652", false},
+		{"42", false},
+		{"c01a2b3c-1a2b-3c4d-5e6f-7a8b9c0d1e2f", false},
+		{"vu9U3hXa3q0AAAABABhidWlsdGluOm1hbmFnZW1lbnQtem9uZXMABnRlbmFudAAGdGVuYW50ACQ0YTc5", true},
+	}
+
+	for _, c := range cases {
+		if output := isSettingsObjectID(c.Input); output != c.ExpectedOutput {
+			t.Fatalf("%q: expected %t, got %t", c.Input, c.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestNewSettingsObjectIDs(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Before         []string
+		After          []string
+		ExpectedOutput []string
+	}{
+		{"created", []string{"a"}, []string{"a", "b"}, []string{"b"}},
+		{"not created", []string{"a"}, []string{"a"}, []string{}},
+		{"first object with the name", []string{}, []string{"b"}, []string{"b"}},
+	}
+
+	for _, c := range cases {
+		if output := newSettingsObjectIDs(c.Before, c.After); !reflect.DeepEqual(output, c.ExpectedOutput) {
+			t.Fatalf("%s: expected %v, got %v", c.Name, c.ExpectedOutput, output)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package dynatrace
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// listsToSetsStateUpgrader returns the state upgrader for the schema version before the
+// list attributes at the given paths, like "rule.propagation_types", were turned into sets.
+// Lists and sets of primitives are stored alike in the state, so it is taken over as it is.
+func listsToSetsStateUpgrader(version int, s map[string]*schema.Schema, paths ...string) schema.StateUpgrader {
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    priorSchemaType(s, paths),
+		Upgrade: func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			return rawState, nil
+		},
+	}
+}
+
+// settingsAPIStateUpgrader returns the state upgrader for the schema version before
+// the resource could be managed through the Settings 2.0 API. The ID is replaced by
+// the ID under the API the resource is managed through, like migrateID does. Not
+// every command configures the provider before upgrading states, the IDs are then
+// left to migrateID on the next read.
+func settingsAPIStateUpgrader(version int, s map[string]*schema.Schema, r *settingsAPIResource) schema.StateUpgrader {
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    (&schema.Resource{Schema: s}).CoreConfigSchema().ImpliedType(),
+		Upgrade: func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			providerConf, ok := meta.(*ProviderConfiguration)
+			id, _ := rawState["id"].(string)
+			if !ok || providerConf == nil || len(id) == 0 {
+				return rawState, nil
+			}
+
+			api, _ := rawState["api"].(string)
+			migrated, err := r.migratedID(ctx, providerConf, api, id)
+			if err != nil {
+				return nil, err
+			}
+
+			rawState["id"] = migrated
+			return rawState, nil
+		},
+	}
+}
+
+func priorSchemaType(s map[string]*schema.Schema, paths []string) cty.Type {
+	r := &schema.Resource{Schema: schemaWithLists(s, paths)}
+	return r.CoreConfigSchema().ImpliedType()
+}
+
+// schemaWithLists copies a schema with the attributes at the given paths turned back into lists.
+func schemaWithLists(s map[string]*schema.Schema, paths []string) map[string]*schema.Schema {
+	copied := make(map[string]*schema.Schema, len(s))
+	for k, v := range s {
+		copied[k] = v
+	}
+
+	for _, path := range paths {
+		parts := strings.SplitN(path, ".", 2)
+
+		attribute := *copied[parts[0]]
+		if len(parts) == 1 {
+			attribute.Type = schema.TypeList
+		} else {
+			elem := *attribute.Elem.(*schema.Resource)
+			elem.Schema = schemaWithLists(elem.Schema, parts[1:])
+			attribute.Elem = &elem
+		}
+		copied[parts[0]] = &attribute
+	}
+
+	return copied
+}
@@ -0,0 +1,98 @@
+package dynatrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestListsToSetsStateUpgrader(t *testing.T) {
+	r := resourceDynatraceManagementZone()
+
+	prior := r.StateUpgraders[0].Type.AttributeType("rule").ElementType().AttributeType("propagation_types")
+	if !prior.Equals(cty.List(cty.String)) {
+		t.Fatalf("Expected propagation types to be a list of strings before version 1, got %s", prior.FriendlyName())
+	}
+
+	current := r.CoreConfigSchema().ImpliedType().AttributeType("rule").ElementType().AttributeType("propagation_types")
+	if !current.Equals(cty.Set(cty.String)) {
+		t.Fatalf("Expected propagation types to be a set of strings, got %s", current.FriendlyName())
+	}
+
+	// the prior schema is a copy, the schema of the resource must be left alone
+	if r.Schema["rule"].Elem.(*schema.Resource).Schema["propagation_types"].Type != schema.TypeSet {
+		t.Fatalf("Expected the schema of the resource to be left alone")
+	}
+
+	state := map[string]interface{}{
+		"name": "Production",
+		"rule": []interface{}{
+			map[string]interface{}{"type": "SERVICE", "propagation_types": []interface{}{"SERVICE_TO_HOST_LIKE", "SERVICE_TO_PROCESS_GROUP_LIKE"}},
+		},
+	}
+	upgraded, err := r.StateUpgraders[0].Upgrade(context.Background(), state, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if len(upgraded["rule"].([]interface{})[0].(map[string]interface{})["propagation_types"].([]interface{})) != 2 {
+		t.Fatalf("Expected the propagation types to be taken over, got %#v", upgraded)
+	}
+}
+
+func TestSettingsAPIStateUpgrader(t *testing.T) {
+	configID := "c01a2b3c-1a2b-3c4d-5e6f-7a8b9c0d1e2f"
+	objectID := "vu9U3hXa3q0AAAABABhidWlsdGluOmFsZXJ0aW5nLnByb2ZpbGUABnRlbmFudAAGdGVuYW50ACQ0YTc5"
+
+	cases := []struct {
+		Name           string
+		Meta           interface{}
+		Input          map[string]interface{}
+		ExpectedOutput string
+	}{
+		{
+			"unconfigured provider",
+			nil,
+			map[string]interface{}{"id": configID, "api": "settings"},
+			configID,
+		},
+		{
+			"config API",
+			&ProviderConfiguration{},
+			map[string]interface{}{"id": configID},
+			configID,
+		},
+		{
+			"settings API",
+			&ProviderConfiguration{DefaultAPI: "settings"},
+			map[string]interface{}{"id": objectID},
+			objectID,
+		},
+	}
+
+	resources := map[string]*schema.Resource{
+		"management zone":    resourceDynatraceManagementZone(),
+		"auto tag":           resourceDynatraceAutoTag(),
+		"alerting profile":   resourceDynatraceAlertingProfile(),
+		"notification":       resourceDynatraceNotification(),
+		"maintenance window": resourceDynatraceMaintenanceWindow(),
+	}
+
+	for name, r := range resources {
+		upgrader := r.StateUpgraders[len(r.StateUpgraders)-1]
+		if upgrader.Version != r.SchemaVersion-1 {
+			t.Fatalf("%s: expected the last state upgrader to upgrade to version %d, got %d", name, r.SchemaVersion, upgrader.Version+1)
+		}
+
+		for _, tc := range cases {
+			upgraded, err := upgrader.Upgrade(context.Background(), tc.Input, tc.Meta)
+			if err != nil {
+				t.Fatalf("%s, %s: unexpected error: %s", name, tc.Name, err)
+			}
+			if upgraded["id"] != tc.ExpectedOutput {
+				t.Fatalf("%s, %s: expected the ID %s, got %v", name, tc.Name, tc.ExpectedOutput, upgraded["id"])
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package dynatrace
+
+import (
+	"fmt"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// alertingProfileSettingsSeverityLevels maps the severity levels of the config v1
+// API that Settings 2.0 names differently.
+var alertingProfileSettingsSeverityLevels = map[string]string{
+	"ERROR": "ERRORS",
+}
+
+// alertingProfileSettingsOperators maps the operators of custom text filters of the
+// config v1 API that Settings 2.0 names differently.
+var alertingProfileSettingsOperators = map[string]string{
+	"CONTAINS_REGEX": "REGEX_MATCHES",
+	"EQUALS":         "STRING_EQUALS",
+}
+
+// settingsRename returns the name of a value under Settings 2.0, or under the
+// config v1 API if reverse is set, which is the value itself unless renamed.
+func settingsRename(renames map[string]string, value string, reverse bool) string {
+	for configName, settingsName := range renames {
+		if !reverse && value == configName {
+			return settingsName
+		}
+		if reverse && value == settingsName {
+			return configName
+		}
+	}
+	return value
+}
+
+// expandAlertingProfileSettings returns the value of the builtin:alerting.profile
+// settings object for an alerting profile.
+func expandAlertingProfileSettings(d *schema.ResourceData) (map[string]interface{}, error) {
+	ap, err := expandAlertingProfile(d)
+	if err != nil {
+		return nil, err
+	}
+
+	severityRules := []interface{}{}
+	if ap.Rules != nil {
+		for _, rule := range *ap.Rules {
+			tags := []interface{}{}
+			if rule.TagFilter.TagFilters != nil {
+				for _, tag := range *rule.TagFilter.TagFilters {
+					tags = append(tags, formatSettingsTag(map[string]interface{}{"context": tag.Context, "key": tag.Key, "value": tag.GetValue()}))
+				}
+			}
+
+			includeMode := rule.TagFilter.IncludeMode
+			if len(includeMode) == 0 {
+				includeMode = "NONE"
+			}
+
+			severityRules = append(severityRules, map[string]interface{}{
+				"severityLevel":        settingsRename(alertingProfileSettingsSeverityLevels, rule.SeverityLevel, false),
+				"delayInMinutes":       rule.DelayInMinutes,
+				"tagFilterIncludeMode": includeMode,
+				"tagFilter":            tags,
+			})
+		}
+	}
+
+	eventFilters := []interface{}{}
+	if ap.EventTypeFilters != nil {
+		for i, filter := range *ap.EventTypeFilters {
+			switch {
+			case filter.PredefinedEventFilter != nil:
+				eventFilters = append(eventFilters, map[string]interface{}{
+					"type": "PREDEFINED",
+					"predefinedFilter": map[string]interface{}{
+						"eventType": filter.PredefinedEventFilter.EventType,
+						"negate":    filter.PredefinedEventFilter.Negate,
+					},
+				})
+			case filter.CustomEventFilter != nil:
+				customFilter := map[string]interface{}{}
+				if filter.CustomEventFilter.CustomTitleFilter != nil {
+					customFilter["titleFilter"] = expandAlertingProfileSettingsTextFilter(filter.CustomEventFilter.CustomTitleFilter)
+				}
+				if filter.CustomEventFilter.CustomDescriptionFilter != nil {
+					customFilter["descriptionFilter"] = expandAlertingProfileSettingsTextFilter(filter.CustomEventFilter.CustomDescriptionFilter)
+				}
+
+				eventFilters = append(eventFilters, map[string]interface{}{
+					"type":         "CUSTOM",
+					"customFilter": customFilter,
+				})
+			default:
+				return nil, fmt.Errorf("event_type_filter.%d: requires a predefined_event_filter or a custom_event_filter", i)
+			}
+		}
+	}
+
+	value := map[string]interface{}{
+		"name":          ap.DisplayName,
+		"severityRules": severityRules,
+		"eventFilters":  eventFilters,
+	}
+	if ap.MzId != nil {
+		value["managementZone"] = *ap.MzId
+	}
+
+	return value, nil
+}
+
+func expandAlertingProfileSettingsTextFilter(filter *dynatraceConfigV1.AlertingCustomTextFilter) map[string]interface{} {
+	return map[string]interface{}{
+		"enabled":       filter.Enabled,
+		"operator":      settingsRename(alertingProfileSettingsOperators, filter.Operator, false),
+		"value":         filter.Value,
+		"negate":        filter.Negate,
+		"caseSensitive": !filter.CaseInsensitive,
+	}
+}
+
+// flattenAlertingProfileSettings turns the value of a builtin:alerting.profile
+// settings object into an alerting profile of the config v1 API, which the
+// attributes are flattened from.
+func flattenAlertingProfileSettings(value map[string]interface{}) *dynatraceConfigV1.AlertingProfile {
+	ap := dynatraceConfigV1.NewAlertingProfileWithDefaults()
+	ap.DisplayName = settingsValueName(value)
+
+	if mzID, ok := value["managementZone"].(string); ok && len(mzID) != 0 {
+		ap.SetMzId(mzID)
+	}
+
+	rules := []dynatraceConfigV1.AlertingProfileSeverityRule{}
+	severityRules, _ := value["severityRules"].([]interface{})
+	for _, severityRule := range severityRules {
+		m, _ := severityRule.(map[string]interface{})
+
+		var rule dynatraceConfigV1.AlertingProfileSeverityRule
+		severityLevel, _ := m["severityLevel"].(string)
+		rule.SeverityLevel = settingsRename(alertingProfileSettingsSeverityLevels, severityLevel, true)
+		rule.DelayInMinutes = int32(settingsInt(m["delayInMinutes"]))
+		rule.TagFilter.IncludeMode, _ = m["tagFilterIncludeMode"].(string)
+
+		tagFilters := []dynatraceConfigV1.TagFilter{}
+		tags, _ := m["tagFilter"].([]interface{})
+		for _, tag := range tags {
+			t := parseSettingsTag(fmt.Sprint(tag))
+
+			var tagFilter dynatraceConfigV1.TagFilter
+			tagFilter.Context = t["context"].(string)
+			tagFilter.Key = t["key"].(string)
+			if v, ok := t["value"].(string); ok {
+				tagFilter.SetValue(v)
+			}
+			tagFilters = append(tagFilters, tagFilter)
+		}
+		rule.TagFilter.SetTagFilters(tagFilters)
+
+		rules = append(rules, rule)
+	}
+	ap.SetRules(rules)
+
+	filters := []dynatraceConfigV1.AlertingEventTypeFilter{}
+	eventFilters, _ := value["eventFilters"].([]interface{})
+	for _, eventFilter := range eventFilters {
+		m, _ := eventFilter.(map[string]interface{})
+
+		switch m["type"] {
+		case "PREDEFINED":
+			predefinedFilter, _ := m["predefinedFilter"].(map[string]interface{})
+			eventType, _ := predefinedFilter["eventType"].(string)
+			negate, _ := predefinedFilter["negate"].(bool)
+
+			filters = append(filters, dynatraceConfigV1.AlertingEventTypeFilter{
+				PredefinedEventFilter: &dynatraceConfigV1.AlertingPredefinedEventFilter{EventType: eventType, Negate: negate},
+			})
+		case "CUSTOM":
+			customFilter, _ := m["customFilter"].(map[string]interface{})
+
+			filters = append(filters, dynatraceConfigV1.AlertingEventTypeFilter{
+				CustomEventFilter: &dynatraceConfigV1.AlertingCustomEventFilter{
+					CustomTitleFilter:       flattenAlertingProfileSettingsTextFilter(customFilter["titleFilter"]),
+					CustomDescriptionFilter: flattenAlertingProfileSettingsTextFilter(customFilter["descriptionFilter"]),
+				},
+			})
+		}
+	}
+	ap.SetEventTypeFilters(filters)
+
+	return ap
+}
+
+func flattenAlertingProfileSettingsTextFilter(v interface{}) *dynatraceConfigV1.AlertingCustomTextFilter {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var filter dynatraceConfigV1.AlertingCustomTextFilter
+	filter.Enabled, _ = m["enabled"].(bool)
+	filter.Value, _ = m["value"].(string)
+	operator, _ := m["operator"].(string)
+	filter.Operator = settingsRename(alertingProfileSettingsOperators, operator, true)
+	filter.Negate, _ = m["negate"].(bool)
+	caseSensitive, _ := m["caseSensitive"].(bool)
+	filter.CaseInsensitive = !caseSensitive
+
+	return &filter
+}
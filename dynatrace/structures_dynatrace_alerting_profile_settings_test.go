@@ -0,0 +1,93 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAlertingProfileSettingsRoundTrip(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDynatraceAlertingProfile().Schema, map[string]interface{}{
+		"display_name": "sockshop",
+		"mz_id":        "-6239538939987181652",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"severity_level":   "ERROR",
+				"delay_in_minutes": 5,
+				"tag_filters": []interface{}{
+					map[string]interface{}{
+						"include_mode": "INCLUDE_ALL",
+						"tag_filter": []interface{}{
+							map[string]interface{}{"context": "KUBERNETES", "key": "app", "value": "carts"},
+						},
+					},
+				},
+			},
+		},
+		"event_type_filter": []interface{}{
+			map[string]interface{}{
+				"predefined_event_filter": []interface{}{
+					map[string]interface{}{"event_type": "OSI_HIGH_CPU", "negate": true},
+				},
+			},
+			map[string]interface{}{
+				"custom_event_filter": []interface{}{
+					map[string]interface{}{
+						"custom_title_filter": []interface{}{
+							map[string]interface{}{"enabled": true, "value": "carts.*", "operator": "CONTAINS_REGEX", "negate": false, "case_insensitive": true},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	value, err := expandAlertingProfileSettings(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"name":           "sockshop",
+		"managementZone": "-6239538939987181652",
+		"severityRules": []interface{}{
+			map[string]interface{}{
+				"severityLevel":        "ERRORS",
+				"delayInMinutes":       int32(5),
+				"tagFilterIncludeMode": "INCLUDE_ALL",
+				"tagFilter":            []interface{}{"[KUBERNETES]app:carts"},
+			},
+		},
+		"eventFilters": []interface{}{
+			map[string]interface{}{
+				"type":             "PREDEFINED",
+				"predefinedFilter": map[string]interface{}{"eventType": "OSI_HIGH_CPU", "negate": true},
+			},
+			map[string]interface{}{
+				"type": "CUSTOM",
+				"customFilter": map[string]interface{}{
+					"titleFilter": map[string]interface{}{"enabled": true, "operator": "REGEX_MATCHES", "value": "carts.*", "negate": false, "caseSensitive": false},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(value, expected) {
+		t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v", expected, value)
+	}
+
+	ap := flattenAlertingProfileSettings(value)
+	original, _ := expandAlertingProfile(d)
+
+	if !reflect.DeepEqual(flattenAlertingProfileRulesData(ap.Rules), flattenAlertingProfileRulesData(original.Rules)) {
+		t.Fatalf("Unexpected rules from flattener.\nExpected: %#v\nGiven:    %#v", flattenAlertingProfileRulesData(original.Rules), flattenAlertingProfileRulesData(ap.Rules))
+	}
+
+	if !reflect.DeepEqual(flattenAlertingProfileEventTypeFiltersData(ap.EventTypeFilters), flattenAlertingProfileEventTypeFiltersData(original.EventTypeFilters)) {
+		t.Fatalf("Unexpected event type filters from flattener.\nExpected: %#v\nGiven:    %#v", flattenAlertingProfileEventTypeFiltersData(original.EventTypeFilters), flattenAlertingProfileEventTypeFiltersData(ap.EventTypeFilters))
+	}
+
+	if ap.DisplayName != "sockshop" || ap.GetMzId() != "-6239538939987181652" {
+		t.Fatalf("Unexpected alerting profile from flattener: %#v", ap)
+	}
+}
@@ -23,7 +23,7 @@ func expandApiTokenCreate(d *schema.ResourceData) (*dynatraceEnvironmentV2.ApiTo
 	}
 
 	if scopes, ok := d.GetOk("scopes"); ok {
-		dtApiToken.SetScopes(expandApiTokenScopes(scopes.([]interface{})))
+		dtApiToken.SetScopes(expandApiTokenScopes(scopes.(*schema.Set).List()))
 	}
 
 	return &dtApiToken, nil
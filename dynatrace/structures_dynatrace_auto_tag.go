@@ -46,7 +46,7 @@ func expandAutoTagRules(rules []interface{}) []dynatraceConfigV1.AutoTagRule {
 		}
 
 		if propagationTypes, ok := m["propagation_types"]; ok {
-			dtAutoTagRule.SetPropagationTypes(expandTagRulePropagationTypes(propagationTypes.([]interface{})))
+			dtAutoTagRule.SetPropagationTypes(expandTagRulePropagationTypes(propagationTypes.(*schema.Set).List()))
 		}
 
 		if conditions, ok := m["condition"].([]interface{}); ok {
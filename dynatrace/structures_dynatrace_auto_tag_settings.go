@@ -0,0 +1,109 @@
+package dynatrace
+
+import (
+	"fmt"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// autoTagValueNormalizations are the ways Settings 2.0 normalizes tag values.
+var autoTagValueNormalizations = []string{"Leave text as-is", "To lower case", "To upper case"}
+
+// expandAutoTagSettings returns the value of the builtin:tags.auto-tagging
+// settings object for an auto tag.
+func expandAutoTagSettings(d *schema.ResourceData) (map[string]interface{}, error) {
+	at, err := expandAutoTag(d)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []interface{}{}
+
+	if at.Rules != nil {
+		for i, rule := range *at.Rules {
+			attributeRule, err := expandSettingsAttributeRule(rule.Type, rule.PropagationTypes, rule.Conditions, "conditions")
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: %s", i, err)
+			}
+
+			rules = append(rules, map[string]interface{}{
+				"enabled":            rule.Enabled,
+				"type":               "ME",
+				"valueFormat":        rule.GetValueFormat(),
+				"valueNormalization": autoTagValueNormalizations[0],
+				"attributeRule":      attributeRule,
+			})
+		}
+	}
+
+	for _, rule := range d.Get("entity_selector_rule").([]interface{}) {
+		m := rule.(map[string]interface{})
+		rules = append(rules, map[string]interface{}{
+			"enabled":            m["enabled"],
+			"type":               "SELECTOR",
+			"entitySelector":     m["selector"],
+			"valueFormat":        m["value_format"],
+			"valueNormalization": m["value_normalization"],
+		})
+	}
+
+	return map[string]interface{}{
+		"name":  at.Name,
+		"rules": rules,
+	}, nil
+}
+
+// flattenAutoTagSettings turns the value of a builtin:tags.auto-tagging settings
+// object into an auto tag of the config v1 API, which the rules are flattened
+// from, and the entity_selector_rule blocks.
+func flattenAutoTagSettings(value map[string]interface{}) (*dynatraceConfigV1.AutoTag, []interface{}, error) {
+	at := dynatraceConfigV1.NewAutoTagWithDefaults()
+	at.Name = settingsValueName(value)
+
+	rules := []dynatraceConfigV1.AutoTagRule{}
+	selectorRules := []interface{}{}
+
+	settingsRules, _ := value["rules"].([]interface{})
+	for i, settingsRule := range settingsRules {
+		m, _ := settingsRule.(map[string]interface{})
+		enabled, _ := m["enabled"].(bool)
+		valueFormat, _ := m["valueFormat"].(string)
+		valueNormalization, _ := m["valueNormalization"].(string)
+		if len(valueNormalization) == 0 {
+			valueNormalization = autoTagValueNormalizations[0]
+		}
+
+		switch m["type"] {
+		case "ME":
+			attributeRule, _ := m["attributeRule"].(map[string]interface{})
+			entityType, propagationTypes, conditions, err := flattenSettingsAttributeRule(attributeRule, "conditions")
+			if err != nil {
+				return nil, nil, fmt.Errorf("rule %d: %s", i, err)
+			}
+
+			rule := dynatraceConfigV1.AutoTagRule{
+				Type:             entityType,
+				Enabled:          enabled,
+				PropagationTypes: propagationTypes,
+				Conditions:       conditions,
+			}
+			if len(valueFormat) != 0 {
+				rule.SetValueFormat(valueFormat)
+			}
+
+			rules = append(rules, rule)
+		case "SELECTOR":
+			selectorRules = append(selectorRules, map[string]interface{}{
+				"enabled":             enabled,
+				"selector":            m["entitySelector"],
+				"value_format":        valueFormat,
+				"value_normalization": valueNormalization,
+			})
+		}
+	}
+
+	at.SetRules(rules)
+
+	return at, selectorRules, nil
+}
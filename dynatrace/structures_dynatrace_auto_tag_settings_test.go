@@ -0,0 +1,88 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAutoTagSettingsRoundTrip(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDynatraceAutoTag().Schema, map[string]interface{}{
+		"name": "Infrastructure",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"type":         "HOST",
+				"enabled":      true,
+				"value_format": "{Host:DetectedName}",
+				"condition": []interface{}{
+					map[string]interface{}{
+						"key": []interface{}{
+							map[string]interface{}{"attribute": "HOST_OS_TYPE"},
+						},
+						"comparison_info": []interface{}{
+							map[string]interface{}{
+								"operator": "EQUALS",
+								"negate":   true,
+								"type":     "OS_TYPE",
+								"os_type":  "WINDOWS",
+							},
+						},
+					},
+				},
+			},
+		},
+		"entity_selector_rule": []interface{}{
+			map[string]interface{}{
+				"selector":            "type(HOST)",
+				"value_format":        "{Host:IpAddress}",
+				"value_normalization": "To lower case",
+			},
+		},
+	})
+
+	value, err := expandAutoTagSettings(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedRules := []interface{}{
+		map[string]interface{}{
+			"enabled":            true,
+			"type":               "ME",
+			"valueFormat":        "{Host:DetectedName}",
+			"valueNormalization": "Leave text as-is",
+			"attributeRule": map[string]interface{}{
+				"entityType": "HOST",
+				"conditions": []interface{}{
+					map[string]interface{}{"key": "HOST_OS_TYPE", "operator": "NOT_EQUALS", "enumValue": "WINDOWS"},
+				},
+			},
+		},
+		map[string]interface{}{
+			"enabled":            true,
+			"type":               "SELECTOR",
+			"entitySelector":     "type(HOST)",
+			"valueFormat":        "{Host:IpAddress}",
+			"valueNormalization": "To lower case",
+		},
+	}
+	if value["name"] != "Infrastructure" || !reflect.DeepEqual(value["rules"], expectedRules) {
+		t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v", expectedRules, value["rules"])
+	}
+
+	at, selectorRules, err := flattenAutoTagSettings(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	rule := (*at.Rules)[0]
+	comparisonInfo := rule.Conditions[0].ComparisonInfo
+	if rule.GetValueFormat() != "{Host:DetectedName}" || comparisonInfo.Type != "OS_TYPE" || !comparisonInfo.Negate || *comparisonInfo.Value != "WINDOWS" {
+		t.Fatalf("Unexpected rule from flattener: %#v", rule)
+	}
+
+	if !reflect.DeepEqual(selectorRules, d.Get("entity_selector_rule")) {
+		t.Fatalf("Unexpected entity selector rules.\nExpected: %#v\nGiven:    %#v", d.Get("entity_selector_rule"), selectorRules)
+	}
+}
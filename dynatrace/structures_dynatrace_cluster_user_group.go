@@ -44,11 +44,11 @@ func expandClusterUserGroup(d *schema.ResourceData) (*dynatraceClusterV1.GroupCo
 	}
 
 	if ldapGroupNames, ok := d.GetOk("ldap_group_names"); ok {
-		dtGroup.SetLdapGroupNames(expandGroupNames(ldapGroupNames.([]interface{})))
+		dtGroup.SetLdapGroupNames(expandGroupNames(ldapGroupNames.(*schema.Set).List()))
 	}
 
 	if ssoGroupNames, ok := d.GetOk("sso_group_names"); ok {
-		dtGroup.SetSsoGroupNames(expandGroupNames(ssoGroupNames.([]interface{})))
+		dtGroup.SetSsoGroupNames(expandGroupNames(ssoGroupNames.(*schema.Set).List()))
 	}
 
 	if accessRight, ok := d.GetOk("access_rights"); ok {
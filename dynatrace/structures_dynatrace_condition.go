@@ -2,7 +2,9 @@ package dynatrace
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
+	"strings"
 
 	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,10 +27,41 @@ func conditionSchema() *schema.Resource {
 						},
 						"dynamic_key": {
 							Type:         schema.TypeString,
-							Description:  "Dynamic key generated based on selected type/attribute.",
+							Description:  "Dynamic key generated based on selected type/attribute, as JSON. Prefer custom_metadata, predefined_key or string_key.",
 							Optional:     true,
 							ValidateFunc: validation.StringIsJSON,
 						},
+						"custom_metadata": &schema.Schema{
+							Type:        schema.TypeList,
+							Description: "The custom metadata used as dynamic key, for the PROCESS_CUSTOM_METADATA_KEY and HOST_CUSTOM_METADATA_KEY types.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"source": &schema.Schema{
+										Type:         schema.TypeString,
+										Description:  "The source of the custom metadata.",
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(conditionCustomMetadataSources, false),
+									},
+									"key": &schema.Schema{
+										Type:        schema.TypeString,
+										Description: "The key of the custom metadata.",
+										Required:    true,
+									},
+								},
+							},
+						},
+						"predefined_key": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The predefined metadata used as dynamic key, for the PROCESS_PREDEFINED_METADATA_KEY type, for example KUBERNETES_NAMESPACE.",
+							Optional:    true,
+						},
+						"string_key": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The dynamic key for the STRING type.",
+							Optional:    true,
+						},
 						"type": &schema.Schema{
 							Type:        schema.TypeString,
 							Description: "Defines the actual set of fields depending on the value.",
@@ -42,7 +75,7 @@ func conditionSchema() *schema.Resource {
 				Description: "Defines how the matching is actually performed: what and how are we comparing.",
 				Required:    true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
+					Schema: conditionComparisonInfoSchema(map[string]*schema.Schema{
 						"operator": &schema.Schema{
 							Type:        schema.TypeString,
 							Description: "Operator of the comparison. You can reverse it by setting negate to true. Possible values depend on the type of the comparison. Find the list of actual models in the description of the type field and check the description of the model you need.",
@@ -50,7 +83,7 @@ func conditionSchema() *schema.Resource {
 						},
 						"value": {
 							Type:         schema.TypeString,
-							Description:  "The value to compare to.",
+							Description:  "The value to compare to, as JSON. Prefer the attribute named after the comparison type, for example service_type.",
 							Optional:     true,
 							ValidateFunc: validation.StringIsJSON,
 						},
@@ -69,13 +102,94 @@ func conditionSchema() *schema.Resource {
 							Description: "Defines if value to compare to is case sensitive",
 							Optional:    true,
 						},
-					},
+					}),
 				},
 			},
 		},
 	}
 }
 
+// conditionComparisonInfoSchema adds to the comparison_info attributes one typed
+// value attribute per comparison type, as an alternative to the JSON value.
+func conditionComparisonInfoSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for _, name := range conditionComparisonTypeNames() {
+		t := conditionComparisonTypes[name]
+		description := fmt.Sprintf("The value to compare to for the %s comparison type.", name)
+
+		switch t.kind {
+		case conditionValueString:
+			s[conditionValueAttribute(name)] = &schema.Schema{
+				Type:        schema.TypeString,
+				Description: description,
+				Optional:    true,
+			}
+		case conditionValueEnum:
+			s[conditionValueAttribute(name)] = &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  description,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(t.values, false),
+			}
+		case conditionValueInteger:
+			s[conditionValueAttribute(name)] = &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: description + " Use value for 0.",
+				Optional:    true,
+			}
+		case conditionValueTag:
+			s[conditionValueAttribute(name)] = &schema.Schema{
+				Type:        schema.TypeList,
+				Description: description,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"context": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "The origin of the tag, such as AWS or Cloud Foundry.",
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(conditionTagContexts, false),
+						},
+						"key": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The key of the tag.",
+							Required:    true,
+						},
+						"value": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The value of the tag. Not applicable to the TAG_KEY_EQUALS operator.",
+							Optional:    true,
+						},
+					},
+				},
+			}
+		case conditionValueTech:
+			s[conditionValueAttribute(name)] = &schema.Schema{
+				Type:        schema.TypeList,
+				Description: description,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The predefined type of the technology, for example JAVA.",
+							Optional:    true,
+						},
+						"verbatim_type": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "The non-predefined type of the technology.",
+							Optional:    true,
+						},
+					},
+				},
+			}
+		}
+	}
+
+	return s
+}
+
 func expandConditions(conditions []interface{}) []dynatraceConfigV1.EntityRuleEngineCondition {
 	if len(conditions) < 1 {
 		return []dynatraceConfigV1.EntityRuleEngineCondition{}
@@ -116,7 +230,9 @@ func expandConditionKey(conditionKey []interface{}) dynatraceConfigV1.ConditionK
 		dtConditionKey.SetAttribute(attribute)
 	}
 
-	if dynamicKey, ok := m["dynamic_key"].(string); ok && len(dynamicKey) != 0 {
+	if dynamicKey, ok := expandConditionTypedDynamicKey(m); ok {
+		dtConditionKey.SetDynamicKey(dynamicKey)
+	} else if dynamicKey, ok := m["dynamic_key"].(string); ok && len(dynamicKey) != 0 {
 		dtConditionKey.SetDynamicKey(expandDynamicKey(dynamicKey))
 	}
 
@@ -128,6 +244,28 @@ func expandConditionKey(conditionKey []interface{}) dynatraceConfigV1.ConditionK
 
 }
 
+// expandConditionTypedDynamicKey returns the dynamic key given by custom_metadata,
+// predefined_key or string_key, if one of them is set.
+func expandConditionTypedDynamicKey(m map[string]interface{}) (interface{}, bool) {
+	if customMetadata, ok := m["custom_metadata"].([]interface{}); ok && len(customMetadata) != 0 && customMetadata[0] != nil {
+		cm := customMetadata[0].(map[string]interface{})
+		return map[string]interface{}{
+			"source": cm["source"],
+			"key":    cm["key"],
+		}, true
+	}
+
+	if predefinedKey, ok := m["predefined_key"].(string); ok && len(predefinedKey) != 0 {
+		return predefinedKey, true
+	}
+
+	if stringKey, ok := m["string_key"].(string); ok && len(stringKey) != 0 {
+		return stringKey, true
+	}
+
+	return nil, false
+}
+
 func expandDynamicKey(key interface{}) interface{} {
 	var val interface{}
 	if err := json.Unmarshal([]byte(key.(string)), &val); err != nil {
@@ -151,7 +289,9 @@ func expandConditionComparisonInfo(comparisonInfo []interface{}) dynatraceConfig
 		dtComparsionInfo.SetOperator(operator)
 	}
 
-	if value, ok := m["value"].(string); ok && len(value) != 0 {
+	if value, ok := expandConditionTypedValue(m); ok {
+		dtComparsionInfo.SetValue(value)
+	} else if value, ok := m["value"].(string); ok && len(value) != 0 {
 		dtComparsionInfo.SetValue(expandComparisonInfoValue(value))
 	}
 
@@ -171,6 +311,56 @@ func expandConditionComparisonInfo(comparisonInfo []interface{}) dynatraceConfig
 
 }
 
+// expandConditionTypedValue returns the value of the typed attribute of the
+// comparison type, if it is set.
+func expandConditionTypedValue(m map[string]interface{}) (interface{}, bool) {
+	ciType, _ := m["type"].(string)
+
+	t, ok := conditionComparisonTypes[ciType]
+	if !ok {
+		return nil, false
+	}
+
+	attribute := conditionValueAttribute(ciType)
+
+	switch t.kind {
+	case conditionValueString, conditionValueEnum:
+		if value, ok := m[attribute].(string); ok && len(value) != 0 {
+			return value, true
+		}
+	case conditionValueInteger:
+		if value, ok := m[attribute].(int); ok && value != 0 {
+			return value, true
+		}
+	case conditionValueTag:
+		if tag, ok := m[attribute].([]interface{}); ok && len(tag) != 0 && tag[0] != nil {
+			tm := tag[0].(map[string]interface{})
+			value := map[string]interface{}{
+				"context": tm["context"],
+				"key":     tm["key"],
+			}
+			if tagValue, ok := tm["value"].(string); ok && len(tagValue) != 0 {
+				value["value"] = tagValue
+			}
+			return value, true
+		}
+	case conditionValueTech:
+		if tech, ok := m[attribute].([]interface{}); ok && len(tech) != 0 && tech[0] != nil {
+			tm := tech[0].(map[string]interface{})
+			value := map[string]interface{}{}
+			if techType, ok := tm["type"].(string); ok && len(techType) != 0 {
+				value["type"] = techType
+			}
+			if verbatimType, ok := tm["verbatim_type"].(string); ok && len(verbatimType) != 0 {
+				value["verbatimType"] = verbatimType
+			}
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
 func expandComparisonInfoValue(value interface{}) interface{} {
 	var val interface{}
 	if err := json.Unmarshal([]byte(value.(string)), &val); err != nil {
@@ -248,3 +438,331 @@ func flattenComparisonInfoValue(value interface{}) interface{} {
 	return string(json)
 
 }
+
+// selectConditionValueForms moves the JSON comparison values and dynamic keys of
+// flattened rules into their typed attributes where the state holds the typed
+// form, so that reading the rules back doesn't produce a diff. Imported rules
+// keep the JSON form.
+func selectConditionValueForms(d *schema.ResourceData, rules []interface{}) {
+	typed := make([]string, 0, len(conditionComparisonTypes))
+	for _, name := range conditionComparisonTypeNames() {
+		typed = append(typed, conditionValueAttribute(name))
+	}
+
+	for i, rule := range rules {
+		conditions, _ := rule.(map[string]interface{})["condition"].([]interface{})
+
+		for j, condition := range conditions {
+			mc := condition.(map[string]interface{})
+			prefix := fmt.Sprintf("rule.%d.condition.%d.", i, j)
+
+			if key, ok := mc["key"].([]interface{}); ok && len(key) != 0 && key[0] != nil {
+				k := key[0].(map[string]interface{})
+				if conditionFormIsTyped(d, prefix+"key.0.", "dynamic_key", []string{"custom_metadata", "predefined_key", "string_key"}) {
+					flattenConditionTypedDynamicKey(k)
+				}
+			}
+
+			if comparisonInfo, ok := mc["comparison_info"].([]interface{}); ok && len(comparisonInfo) != 0 && comparisonInfo[0] != nil {
+				c := comparisonInfo[0].(map[string]interface{})
+				if conditionFormIsTyped(d, prefix+"comparison_info.0.", "value", typed) {
+					flattenConditionTypedValue(c)
+				}
+			}
+		}
+	}
+}
+
+// flattenConditionTypedDynamicKey replaces the JSON dynamic key of a flattened
+// condition key with custom_metadata, predefined_key or string_key.
+func flattenConditionTypedDynamicKey(k map[string]interface{}) {
+	dynamicKey, ok := k["dynamic_key"].(string)
+	if !ok {
+		return
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(dynamicKey), &value); err != nil {
+		return
+	}
+
+	keyType, _ := k["type"].(*string)
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		k["custom_metadata"] = []interface{}{
+			map[string]interface{}{
+				"source": v["source"],
+				"key":    v["key"],
+			},
+		}
+	case string:
+		if keyType != nil && *keyType == "STRING" {
+			k["string_key"] = v
+		} else {
+			k["predefined_key"] = v
+		}
+	default:
+		return
+	}
+
+	delete(k, "dynamic_key")
+}
+
+// flattenConditionTypedValue replaces the JSON value of a flattened comparison
+// with the typed attribute of its comparison type.
+func flattenConditionTypedValue(c map[string]interface{}) {
+	ciType, _ := c["type"].(string)
+
+	t, ok := conditionComparisonTypes[ciType]
+	if !ok {
+		return
+	}
+
+	jsonValue, ok := c["value"].(string)
+	if !ok {
+		return
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(jsonValue), &value); err != nil {
+		return
+	}
+
+	var typedValue interface{}
+
+	switch t.kind {
+	case conditionValueString, conditionValueEnum:
+		if v, ok := value.(string); ok {
+			typedValue = v
+		}
+	case conditionValueInteger:
+		if v, ok := value.(float64); ok {
+			typedValue = int(v)
+		}
+	case conditionValueTag:
+		if v, ok := value.(map[string]interface{}); ok {
+			typedValue = []interface{}{
+				map[string]interface{}{
+					"context": v["context"],
+					"key":     v["key"],
+					"value":   v["value"],
+				},
+			}
+		}
+	case conditionValueTech:
+		if v, ok := value.(map[string]interface{}); ok {
+			typedValue = []interface{}{
+				map[string]interface{}{
+					"type":          v["type"],
+					"verbatim_type": v["verbatimType"],
+				},
+			}
+		}
+	}
+
+	if typedValue == nil {
+		return
+	}
+
+	c[conditionValueAttribute(ciType)] = typedValue
+	delete(c, "value")
+}
+
+func conditionFormIsTyped(d *schema.ResourceData, prefix string, jsonAttribute string, typedAttributes []string) bool {
+	if v, ok := d.GetOk(prefix + jsonAttribute); ok && len(v.(string)) != 0 {
+		return false
+	}
+
+	for _, attribute := range typedAttributes {
+		if _, ok := d.GetOk(prefix + attribute); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateRuleConditions checks the conditions of all rules of a management zone
+// or an auto tag against the comparison types they use. Conditions with values
+// that are not known yet are skipped.
+func validateRuleConditions(d *schema.ResourceDiff) []error {
+	var errs []error
+
+	rules, _ := d.Get("rule").([]interface{})
+
+	for i, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		conditions, _ := rule.(map[string]interface{})["condition"].([]interface{})
+
+		for j, condition := range conditions {
+			if condition == nil {
+				continue
+			}
+
+			prefix := fmt.Sprintf("rule.%d.condition.%d.", i, j)
+
+			known := true
+			for _, key := range []string{"key", "comparison_info"} {
+				if !d.NewValueKnown(prefix + key) {
+					known = false
+				}
+			}
+
+			if known {
+				errs = append(errs, validateCondition(condition.(map[string]interface{}), prefix)...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateCondition checks a condition against the catalogs of attributes and
+// comparison types: the dynamic key and the comparison type must fit the
+// attribute, the operator must be supported by the comparison type and the value
+// must be given in a single, matching form.
+func validateCondition(condition map[string]interface{}, prefix string) []error {
+	var errs []error
+
+	var keyAttribute string
+
+	if key, ok := condition["key"].([]interface{}); ok && len(key) != 0 && key[0] != nil {
+		k := key[0].(map[string]interface{})
+		path := prefix + "key.0."
+
+		keyAttribute, _ = k["attribute"].(string)
+		keyType, _ := k["type"].(string)
+
+		// the API reads keys without a dynamic key back as STATIC
+		staticKey := keyType == "STATIC"
+		if staticKey {
+			keyType = ""
+		}
+
+		keys := conditionSetAttributes(k, []string{"dynamic_key", "custom_metadata", "predefined_key", "string_key"})
+		if len(keys) > 1 {
+			errs = append(errs, fmt.Errorf("%s%s: only one of %s can be set", path, keys[1], strings.Join(keys, ", ")))
+		}
+
+		if a, ok := conditionAttributes[keyAttribute]; ok && a.keyType != keyType {
+			if len(a.keyType) == 0 {
+				errs = append(errs, fmt.Errorf("%stype: the %s attribute has no dynamic key, got %s", path, keyAttribute, keyType))
+			} else {
+				errs = append(errs, fmt.Errorf("%stype: must be %s for the %s attribute", path, a.keyType, keyAttribute))
+			}
+		}
+
+		if len(keyType) != 0 {
+			if typedKey, ok := conditionKeyTypes[keyType]; !ok {
+				errs = append(errs, fmt.Errorf("%stype: %s is not a dynamic key type, use one of %s", path, keyType, strings.Join(conditionKeyTypeNames(), ", ")))
+			} else if len(keys) == 0 {
+				errs = append(errs, fmt.Errorf("%s%s: is required for the %s key type", path, typedKey, keyType))
+			} else {
+				for _, key := range keys {
+					if key != "dynamic_key" && key != typedKey {
+						errs = append(errs, fmt.Errorf("%s%s: doesn't apply to the %s key type, use %s", path, key, keyType, typedKey))
+					}
+				}
+			}
+		} else if staticKey && len(keys) != 0 {
+			errs = append(errs, fmt.Errorf("%stype: STATIC keys have no %s, use one of %s", path, keys[0], strings.Join(conditionKeyTypeNames(), ", ")))
+		} else if len(keys) != 0 {
+			errs = append(errs, fmt.Errorf("%stype: is required with %s", path, keys[0]))
+		}
+	}
+
+	comparisonInfo, ok := condition["comparison_info"].([]interface{})
+	if !ok || len(comparisonInfo) == 0 || comparisonInfo[0] == nil {
+		return errs
+	}
+
+	c := comparisonInfo[0].(map[string]interface{})
+	path := prefix + "comparison_info.0."
+
+	ciType, _ := c["type"].(string)
+	operator, _ := c["operator"].(string)
+
+	t, ok := conditionComparisonTypes[ciType]
+	if !ok {
+		errs = append(errs, fmt.Errorf("%stype: %s is not a comparison type, use one of %s", path, ciType, strings.Join(conditionComparisonTypeNames(), ", ")))
+		return errs
+	}
+
+	if a, ok := conditionAttributes[keyAttribute]; ok && !conditionContains(a.comparisonTypes, ciType) {
+		errs = append(errs, fmt.Errorf("%stype: the %s attribute is compared as %s, got %s", path, keyAttribute, strings.Join(a.comparisonTypes, " or "), ciType))
+	}
+
+	if !conditionContains(t.operators, operator) {
+		errs = append(errs, fmt.Errorf("%soperator: %s is not supported by the %s comparison type, use one of %s", path, operator, ciType, strings.Join(t.operators, ", ")))
+	}
+
+	typed := make([]string, 0, len(conditionComparisonTypes))
+	for _, name := range conditionComparisonTypeNames() {
+		typed = append(typed, conditionValueAttribute(name))
+	}
+
+	values := conditionSetAttributes(c, append([]string{"value"}, typed...))
+	attribute := conditionValueAttribute(ciType)
+
+	for _, value := range values {
+		if value != "value" && value != attribute {
+			errs = append(errs, fmt.Errorf("%s%s: doesn't apply to the %s comparison type, use %s", path, value, ciType, attribute))
+		}
+	}
+
+	switch {
+	case len(values) > 1:
+		errs = append(errs, fmt.Errorf("%svalue: only one of %s can be set", path, strings.Join(values, ", ")))
+	case operator == "EXISTS" && len(values) != 0:
+		errs = append(errs, fmt.Errorf("%s%s: must not be set for the EXISTS operator", path, values[0]))
+	case operator != "EXISTS" && len(values) == 0:
+		errs = append(errs, fmt.Errorf("%s%s: is required for the %s operator", path, attribute, operator))
+	}
+
+	if t.kind == conditionValueTag && operator == "TAG_KEY_EQUALS" {
+		if tag, ok := c[attribute].([]interface{}); ok && len(tag) != 0 && tag[0] != nil {
+			if value, ok := tag[0].(map[string]interface{})["value"].(string); ok && len(value) != 0 {
+				errs = append(errs, fmt.Errorf("%s%s.0.value: must not be set for the TAG_KEY_EQUALS operator", path, attribute))
+			}
+		}
+	}
+
+	return errs
+}
+
+// conditionSetAttributes returns those of the attributes that hold a value.
+func conditionSetAttributes(m map[string]interface{}, attributes []string) []string {
+	var set []string
+
+	for _, attribute := range attributes {
+		switch v := m[attribute].(type) {
+		case string:
+			if len(v) != 0 {
+				set = append(set, attribute)
+			}
+		case int:
+			if v != 0 {
+				set = append(set, attribute)
+			}
+		case []interface{}:
+			if len(v) != 0 {
+				set = append(set, attribute)
+			}
+		}
+	}
+
+	return set
+}
+
+func conditionContains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,412 @@
+package dynatrace
+
+import (
+	"sort"
+	"strings"
+)
+
+// conditionValueKind is the shape of the value of a comparison, as sent to the API.
+type conditionValueKind int
+
+const (
+	// a plain string
+	conditionValueString conditionValueKind = iota
+	// a string out of a fixed set of values
+	conditionValueEnum
+	// a number
+	conditionValueInteger
+	// an object with context, key and value
+	conditionValueTag
+	// an object with type and verbatimType
+	conditionValueTech
+)
+
+// conditionComparisonType describes a comparison_info.type: the shape of its value
+// and the operators it supports.
+type conditionComparisonType struct {
+	kind      conditionValueKind
+	values    []string
+	operators []string
+}
+
+var conditionEqualsExists = []string{"EQUALS", "EXISTS"}
+
+// conditionComparisonTypes lists the comparison types of the entity rule engine.
+// Each of them has a typed value attribute in comparison_info named after the
+// type in lower case, for example service_type for SERVICE_TYPE.
+var conditionComparisonTypes = map[string]conditionComparisonType{
+	"APPLICATION_TYPE": {
+		kind:      conditionValueEnum,
+		values:    []string{"AGENTLESS_MONITORING", "AUTO_INJECTED", "DEFAULT", "SAAS_VENDOR"},
+		operators: conditionEqualsExists,
+	},
+	"AZURE_COMPUTE_MODE": {
+		kind:      conditionValueEnum,
+		values:    []string{"DEDICATED", "SHARED"},
+		operators: conditionEqualsExists,
+	},
+	"AZURE_SKU": {
+		kind:      conditionValueEnum,
+		values:    []string{"BASIC", "DYNAMIC", "FREE", "PREMIUM", "SHARED", "STANDARD"},
+		operators: conditionEqualsExists,
+	},
+	"BITNESS": {
+		kind:      conditionValueEnum,
+		values:    []string{"32", "64"},
+		operators: conditionEqualsExists,
+	},
+	"CLOUD_TYPE": {
+		kind:      conditionValueEnum,
+		values:    []string{"AZURE", "EC2", "GOOGLE_CLOUD_PLATFORM", "OPENSTACK", "ORACLE", "UNRECOGNIZED"},
+		operators: conditionEqualsExists,
+	},
+	"CUSTOM_APPLICATION_TYPE": {
+		kind:      conditionValueEnum,
+		values:    []string{"AMAZON_ECHO", "DESKTOP", "EMBEDDED", "IOT", "MICROSOFT_HOLOLENS", "UFO"},
+		operators: conditionEqualsExists,
+	},
+	"DATABASE_TOPOLOGY": {
+		kind:      conditionValueEnum,
+		values:    []string{"CLUSTER", "EMBEDDED", "FAILOVER", "IPC", "LOAD_BALANCING", "SINGLE_SERVER", "UNSPECIFIED"},
+		operators: conditionEqualsExists,
+	},
+	"DCRUM_DECODER_TYPE": {
+		kind: conditionValueEnum,
+		values: []string{"ALL_OTHER", "CITRIX_APPFLOW", "CITRIX_ICA", "CITRIX_ICA_OVER_SSL", "DB2_DRDA", "HTTP", "HTTPS",
+			"HTTP_EXPRESS", "INFORMIX", "MYSQL", "ORACLE", "SAP_GUI", "SAP_GUI_OVER_HTTP", "SAP_GUI_OVER_HTTPS",
+			"SAP_HANA_DB", "SAP_RFC", "SSL", "TDS"},
+		operators: conditionEqualsExists,
+	},
+	"ENTITY_ID": {
+		kind:      conditionValueString,
+		operators: []string{"EQUALS"},
+	},
+	"HYPERVISOR_TYPE": {
+		kind:      conditionValueEnum,
+		values:    []string{"AHV", "HYPER_V", "KVM", "LPAR", "QEMU", "VIRTUAL_BOX", "VMWARE", "WPAR", "XEN"},
+		operators: conditionEqualsExists,
+	},
+	"INDEXED_NAME": {
+		kind:      conditionValueString,
+		operators: []string{"BEGINS_WITH", "CONTAINS", "EQUALS", "EXISTS"},
+	},
+	"INDEXED_STRING": {
+		kind:      conditionValueString,
+		operators: conditionEqualsExists,
+	},
+	"INDEXED_TAG": {
+		kind:      conditionValueTag,
+		operators: []string{"EQUALS", "EXISTS", "TAG_KEY_EQUALS"},
+	},
+	"INTEGER": {
+		kind:      conditionValueInteger,
+		operators: []string{"EQUALS", "EXISTS", "GREATER_THAN", "GREATER_THAN_OR_EQUAL", "LOWER_THAN", "LOWER_THAN_OR_EQUAL"},
+	},
+	"IP_ADDRESS": {
+		kind:      conditionValueString,
+		operators: []string{"BEGINS_WITH", "CONTAINS", "ENDS_WITH", "EQUALS", "EXISTS", "IS_IP_IN_RANGE", "REGEX_MATCHES"},
+	},
+	"MOBILE_PLATFORM": {
+		kind:      conditionValueEnum,
+		values:    []string{"ANDROID", "IOS", "LINUX", "MAC_OS", "OTHER", "TVOS", "WINDOWS"},
+		operators: conditionEqualsExists,
+	},
+	"OS_ARCHITECTURE": {
+		kind:      conditionValueEnum,
+		values:    []string{"ARM", "IA64", "PARISC", "PPC", "PPCLE", "S390", "SPARC", "X86", "ZOS"},
+		operators: conditionEqualsExists,
+	},
+	"OS_TYPE": {
+		kind:      conditionValueEnum,
+		values:    []string{"AIX", "DARWIN", "HPUX", "LINUX", "SOLARIS", "WINDOWS", "ZOS"},
+		operators: conditionEqualsExists,
+	},
+	"PAAS_TYPE": {
+		kind: conditionValueEnum,
+		values: []string{"AWS_ECS_EC2", "AWS_ECS_FARGATE", "AWS_LAMBDA", "AZURE_FUNCTIONS", "AZURE_WEBSITES",
+			"CLOUD_FOUNDRY", "GOOGLE_APP_ENGINE", "HEROKU", "KUBERNETES", "OPENSHIFT"},
+		operators: conditionEqualsExists,
+	},
+	"SERVICE_TOPOLOGY": {
+		kind:      conditionValueEnum,
+		values:    []string{"EXTERNAL_SERVICE", "FULLY_MONITORED", "OPAQUE_SERVICE"},
+		operators: conditionEqualsExists,
+	},
+	"SERVICE_TYPE": {
+		kind: conditionValueEnum,
+		values: []string{"BACKGROUND_ACTIVITY", "CICS_SERVICE", "CUSTOM_SERVICE", "DATABASE_SERVICE",
+			"ENTERPRISE_SERVICE_BUS_SERVICE", "EXTERNAL", "IBM_INTEGRATION_BUS_SERVICE", "IMS_SERVICE",
+			"MESSAGING_SERVICE", "QUEUE_LISTENER_SERVICE", "RMI_SERVICE", "RPC_SERVICE", "WEB_REQUEST_SERVICE",
+			"WEB_SERVICE"},
+		operators: conditionEqualsExists,
+	},
+	"SIMPLE_HOST_TECH": {
+		kind:      conditionValueTech,
+		operators: conditionEqualsExists,
+	},
+	"SIMPLE_TECH": {
+		kind:      conditionValueTech,
+		operators: conditionEqualsExists,
+	},
+	"STRING": {
+		kind:      conditionValueString,
+		operators: []string{"BEGINS_WITH", "CONTAINS", "ENDS_WITH", "EQUALS", "EXISTS", "REGEX_MATCHES"},
+	},
+	"SYNTHETIC_ENGINE_TYPE": {
+		kind:      conditionValueEnum,
+		values:    []string{"CLASSIC", "CUSTOM"},
+		operators: conditionEqualsExists,
+	},
+	"TAG": {
+		kind:      conditionValueTag,
+		operators: []string{"EQUALS", "TAG_KEY_EQUALS"},
+	},
+}
+
+// conditionTagContexts are the origins of a tag in a tag comparison.
+var conditionTagContexts = []string{"AWS", "AWS_GENERIC", "AZURE", "CLOUD_FOUNDRY", "CONTEXTLESS", "ENVIRONMENT", "GOOGLE_CLOUD", "KUBERNETES"}
+
+// conditionCustomMetadataSources are the sources of custom metadata used as a dynamic key.
+var conditionCustomMetadataSources = []string{"CLOUD_FOUNDRY", "ENVIRONMENT", "GOOGLE_CLOUD", "KUBERNETES", "PLUGIN"}
+
+// conditionValueAttribute returns the name of the typed value attribute of a comparison type.
+func conditionValueAttribute(comparisonType string) string {
+	return strings.ToLower(comparisonType)
+}
+
+// conditionComparisonTypeNames returns the comparison types in alphabetical order.
+func conditionComparisonTypeNames() []string {
+	names := make([]string, 0, len(conditionComparisonTypes))
+	for name := range conditionComparisonTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// conditionAttribute describes a key.attribute: the comparison types its values
+// can be compared with and, for metadata attributes, the type of its dynamic key.
+type conditionAttribute struct {
+	comparisonTypes []string
+	keyType         string
+}
+
+var (
+	conditionString     = conditionAttribute{comparisonTypes: []string{"STRING"}}
+	conditionEntityID   = conditionAttribute{comparisonTypes: []string{"ENTITY_ID"}}
+	conditionInteger    = conditionAttribute{comparisonTypes: []string{"INTEGER"}}
+	conditionTag        = conditionAttribute{comparisonTypes: []string{"TAG", "INDEXED_TAG"}}
+	conditionIPAddress  = conditionAttribute{comparisonTypes: []string{"IP_ADDRESS"}}
+	conditionSimpleTech = conditionAttribute{comparisonTypes: []string{"SIMPLE_TECH"}}
+)
+
+// conditionAttributes lists the well-known attributes of the entity rule engine.
+// Attributes missing from it are not checked, the API validates them on apply.
+var conditionAttributes = map[string]conditionAttribute{
+	"APPMON_SERVER_NAME":                             conditionString,
+	"APPMON_SYSTEM_PROFILE_NAME":                     conditionString,
+	"AWS_ACCOUNT_ID":                                 conditionString,
+	"AWS_ACCOUNT_NAME":                               conditionString,
+	"AWS_APPLICATION_LOAD_BALANCER_NAME":             conditionString,
+	"AWS_APPLICATION_LOAD_BALANCER_TAGS":             conditionTag,
+	"AWS_AUTO_SCALING_GROUP_NAME":                    conditionString,
+	"AWS_AUTO_SCALING_GROUP_TAGS":                    conditionTag,
+	"AWS_AVAILABILITY_ZONE_NAME":                     conditionString,
+	"AWS_CLASSIC_LOAD_BALANCER_NAME":                 conditionString,
+	"AWS_CLASSIC_LOAD_BALANCER_TAGS":                 conditionTag,
+	"AWS_NETWORK_LOAD_BALANCER_NAME":                 conditionString,
+	"AWS_NETWORK_LOAD_BALANCER_TAGS":                 conditionTag,
+	"AWS_RELATIONAL_DATABASE_SERVICE_DB_NAME":        conditionString,
+	"AWS_RELATIONAL_DATABASE_SERVICE_ENDPOINT":       conditionString,
+	"AWS_RELATIONAL_DATABASE_SERVICE_ENGINE":         conditionString,
+	"AWS_RELATIONAL_DATABASE_SERVICE_INSTANCE_CLASS": conditionString,
+	"AWS_RELATIONAL_DATABASE_SERVICE_NAME":           conditionString,
+	"AWS_RELATIONAL_DATABASE_SERVICE_PORT":           conditionInteger,
+	"AWS_RELATIONAL_DATABASE_SERVICE_TAGS":           conditionTag,
+	"AZURE_ENTITY_NAME":                              conditionString,
+	"AZURE_ENTITY_TAGS":                              conditionTag,
+	"AZURE_MGMT_GROUP_NAME":                          conditionString,
+	"AZURE_MGMT_GROUP_UUID":                          conditionString,
+	"AZURE_REGION_NAME":                              conditionString,
+	"AZURE_SCALE_SET_NAME":                           conditionString,
+	"AZURE_SUBSCRIPTION_NAME":                        conditionString,
+	"AZURE_SUBSCRIPTION_UUID":                        conditionString,
+	"AZURE_TENANT_NAME":                              conditionString,
+	"AZURE_TENANT_UUID":                              conditionString,
+	"AZURE_VM_NAME":                                  conditionString,
+	"BROWSER_MONITOR_NAME":                           conditionString,
+	"BROWSER_MONITOR_TAGS":                           conditionTag,
+	"CLOUD_APPLICATION_NAME":                         conditionString,
+	"CLOUD_APPLICATION_NAMESPACE_NAME":               conditionString,
+	"CLOUD_FOUNDRY_FOUNDATION_NAME":                  conditionString,
+	"CLOUD_FOUNDRY_ORG_NAME":                         conditionString,
+	"CUSTOM_APPLICATION_NAME":                        conditionString,
+	"CUSTOM_APPLICATION_PLATFORM":                    {comparisonTypes: []string{"MOBILE_PLATFORM"}},
+	"CUSTOM_APPLICATION_TAGS":                        conditionTag,
+	"CUSTOM_APPLICATION_TYPE":                        {comparisonTypes: []string{"CUSTOM_APPLICATION_TYPE"}},
+	"CUSTOM_DEVICE_DNS_ADDRESS":                      conditionString,
+	"CUSTOM_DEVICE_GROUP_NAME":                       conditionString,
+	"CUSTOM_DEVICE_GROUP_TAGS":                       conditionTag,
+	"CUSTOM_DEVICE_IP_ADDRESS":                       conditionIPAddress,
+	"CUSTOM_DEVICE_NAME":                             conditionString,
+	"CUSTOM_DEVICE_PORT":                             conditionInteger,
+	"CUSTOM_DEVICE_TAGS":                             conditionTag,
+	"CUSTOM_DEVICE_TECHNOLOGY":                       conditionString,
+	"DATA_CENTER_SERVICE_DECODER_TYPE":               {comparisonTypes: []string{"DCRUM_DECODER_TYPE"}},
+	"DATA_CENTER_SERVICE_IP_ADDRESS":                 conditionIPAddress,
+	"DATA_CENTER_SERVICE_NAME":                       conditionString,
+	"DATA_CENTER_SERVICE_PORT":                       conditionInteger,
+	"DATA_CENTER_SERVICE_TAGS":                       conditionTag,
+	"DOCKER_CONTAINER_NAME":                          conditionString,
+	"DOCKER_FULL_IMAGE_NAME":                         conditionString,
+	"DOCKER_IMAGE_VERSION":                           conditionString,
+	"EC2_INSTANCE_AMI_ID":                            conditionString,
+	"EC2_INSTANCE_AWS_INSTANCE_TYPE":                 conditionString,
+	"EC2_INSTANCE_AWS_SECURITY_GROUP":                conditionString,
+	"EC2_INSTANCE_BEANSTALK_ENV_NAME":                conditionString,
+	"EC2_INSTANCE_ID":                                conditionString,
+	"EC2_INSTANCE_NAME":                              conditionString,
+	"EC2_INSTANCE_PRIVATE_HOST_NAME":                 conditionString,
+	"EC2_INSTANCE_PUBLIC_HOST_NAME":                  conditionString,
+	"EC2_INSTANCE_TAGS":                              conditionTag,
+	"ENTERPRISE_APPLICATION_DECODER_TYPE":            {comparisonTypes: []string{"DCRUM_DECODER_TYPE"}},
+	"ENTERPRISE_APPLICATION_IP_ADDRESS":              conditionIPAddress,
+	"ENTERPRISE_APPLICATION_NAME":                    conditionString,
+	"ENTERPRISE_APPLICATION_PORT":                    conditionInteger,
+	"ENTERPRISE_APPLICATION_TAGS":                    conditionTag,
+	"ESXI_HOST_CLUSTER_NAME":                         conditionString,
+	"ESXI_HOST_HARDWARE_MODEL":                       conditionString,
+	"ESXI_HOST_HARDWARE_VENDOR":                      conditionString,
+	"ESXI_HOST_NAME":                                 conditionString,
+	"ESXI_HOST_PRODUCT_NAME":                         conditionString,
+	"ESXI_HOST_PRODUCT_VERSION":                      conditionString,
+	"ESXI_HOST_TAGS":                                 conditionTag,
+	"EXTERNAL_MONITOR_ENGINE_DESCRIPTION":            conditionString,
+	"EXTERNAL_MONITOR_ENGINE_NAME":                   conditionString,
+	"EXTERNAL_MONITOR_ENGINE_TYPE":                   {comparisonTypes: []string{"SYNTHETIC_ENGINE_TYPE"}},
+	"EXTERNAL_MONITOR_NAME":                          conditionString,
+	"EXTERNAL_MONITOR_TAGS":                          conditionTag,
+	"GEOLOCATION_SITE_NAME":                          conditionString,
+	"GOOGLE_CLOUD_PLATFORM_ZONE_NAME":                conditionString,
+	"GOOGLE_COMPUTE_INSTANCE_ID":                     conditionString,
+	"GOOGLE_COMPUTE_INSTANCE_MACHINE_TYPE":           conditionString,
+	"GOOGLE_COMPUTE_INSTANCE_NAME":                   conditionString,
+	"GOOGLE_COMPUTE_INSTANCE_PROJECT":                conditionString,
+	"GOOGLE_COMPUTE_INSTANCE_PROJECT_ID":             conditionString,
+	"HOST_AIX_LOGICAL_CPU_COUNT":                     conditionInteger,
+	"HOST_AIX_SIMULTANEOUS_THREADS":                  conditionInteger,
+	"HOST_AIX_VIRTUAL_CPU_COUNT":                     conditionInteger,
+	"HOST_ARCHITECTURE":                              {comparisonTypes: []string{"OS_ARCHITECTURE"}},
+	"HOST_AWS_NAME_TAG":                              conditionString,
+	"HOST_AZURE_COMPUTE_MODE":                        {comparisonTypes: []string{"AZURE_COMPUTE_MODE"}},
+	"HOST_AZURE_SKU":                                 {comparisonTypes: []string{"AZURE_SKU"}},
+	"HOST_AZURE_WEB_APPLICATION_HOST_NAMES":          conditionString,
+	"HOST_AZURE_WEB_APPLICATION_SITE_NAMES":          conditionString,
+	"HOST_BITNESS":                                   {comparisonTypes: []string{"BITNESS"}},
+	"HOST_BOSH_AVAILABILITY_ZONE":                    conditionString,
+	"HOST_BOSH_DEPLOYMENT_ID":                        conditionString,
+	"HOST_BOSH_INSTANCE_ID":                          conditionString,
+	"HOST_BOSH_INSTANCE_NAME":                        conditionString,
+	"HOST_BOSH_NAME":                                 conditionString,
+	"HOST_BOSH_STEMCELL_VERSION":                     conditionString,
+	"HOST_CLOUD_TYPE":                                {comparisonTypes: []string{"CLOUD_TYPE"}},
+	"HOST_CPU_CORES":                                 conditionInteger,
+	"HOST_CUSTOM_METADATA":                           {comparisonTypes: []string{"STRING"}, keyType: "HOST_CUSTOM_METADATA_KEY"},
+	"HOST_DETECTED_NAME":                             conditionString,
+	"HOST_GROUP_ID":                                  conditionEntityID,
+	"HOST_GROUP_NAME":                                conditionString,
+	"HOST_HYPERVISOR_TYPE":                           {comparisonTypes: []string{"HYPERVISOR_TYPE"}},
+	"HOST_IP_ADDRESS":                                conditionIPAddress,
+	"HOST_LOGICAL_CPU_CORES":                         conditionInteger,
+	"HOST_NAME":                                      conditionString,
+	"HOST_ONEAGENT_CUSTOM_HOST_NAME":                 conditionString,
+	"HOST_OS_TYPE":                                   {comparisonTypes: []string{"OS_TYPE"}},
+	"HOST_OS_VERSION":                                conditionString,
+	"HOST_PAAS_MEMORY_LIMIT":                         conditionInteger,
+	"HOST_PAAS_TYPE":                                 {comparisonTypes: []string{"PAAS_TYPE"}},
+	"HOST_TAGS":                                      conditionTag,
+	"HOST_TECHNOLOGY":                                {comparisonTypes: []string{"SIMPLE_HOST_TECH"}},
+	"HTTP_MONITOR_NAME":                              conditionString,
+	"HTTP_MONITOR_TAGS":                              conditionTag,
+	"KUBERNETES_CLUSTER_NAME":                        conditionString,
+	"KUBERNETES_NODE_NAME":                           conditionString,
+	"MOBILE_APPLICATION_NAME":                        conditionString,
+	"MOBILE_APPLICATION_PLATFORM":                    {comparisonTypes: []string{"MOBILE_PLATFORM"}},
+	"MOBILE_APPLICATION_TAGS":                        conditionTag,
+	"NAME_OF_COMPUTE_NODE":                           conditionString,
+	"OPENSTACK_ACCOUNT_NAME":                         conditionString,
+	"OPENSTACK_ACCOUNT_PROJECT_NAME":                 conditionString,
+	"OPENSTACK_AVAILABILITY_ZONE_NAME":               conditionString,
+	"OPENSTACK_PROJECT_NAME":                         conditionString,
+	"OPENSTACK_REGION_NAME":                          conditionString,
+	"OPENSTACK_VM_INSTANCE_TYPE":                     conditionString,
+	"OPENSTACK_VM_NAME":                              conditionString,
+	"OPENSTACK_VM_SECURITY_GROUP":                    conditionString,
+	"PROCESS_GROUP_AZURE_HOST_NAME":                  conditionString,
+	"PROCESS_GROUP_AZURE_SITE_NAME":                  conditionString,
+	"PROCESS_GROUP_CUSTOM_METADATA":                  {comparisonTypes: []string{"STRING"}, keyType: "PROCESS_CUSTOM_METADATA_KEY"},
+	"PROCESS_GROUP_DETECTED_NAME":                    conditionString,
+	"PROCESS_GROUP_ID":                               conditionEntityID,
+	"PROCESS_GROUP_LISTEN_PORT":                      conditionInteger,
+	"PROCESS_GROUP_NAME":                             conditionString,
+	"PROCESS_GROUP_PREDEFINED_METADATA":              {comparisonTypes: []string{"STRING"}, keyType: "PROCESS_PREDEFINED_METADATA_KEY"},
+	"PROCESS_GROUP_TAGS":                             conditionTag,
+	"PROCESS_GROUP_TECHNOLOGY":                       conditionSimpleTech,
+	"PROCESS_GROUP_TECHNOLOGY_EDITION":               conditionString,
+	"PROCESS_GROUP_TECHNOLOGY_VERSION":               conditionString,
+	"PROCESS_SUPERVISOR":                             conditionString,
+	"QUEUE_NAME":                                     conditionString,
+	"QUEUE_TECHNOLOGY":                               conditionString,
+	"QUEUE_VENDOR":                                   conditionString,
+	"SERVICE_AKKA_ACTOR_SYSTEM":                      conditionString,
+	"SERVICE_CTG_SERVICE_NAME":                       conditionString,
+	"SERVICE_DATABASE_HOST_NAME":                     conditionString,
+	"SERVICE_DATABASE_NAME":                          conditionString,
+	"SERVICE_DATABASE_TOPOLOGY":                      {comparisonTypes: []string{"DATABASE_TOPOLOGY"}},
+	"SERVICE_DATABASE_VENDOR":                        conditionString,
+	"SERVICE_DETECTED_NAME":                          conditionString,
+	"SERVICE_ESB_APPLICATION_NAME":                   conditionString,
+	"SERVICE_IBM_CTG_GATEWAY_URL":                    conditionString,
+	"SERVICE_MESSAGING_LISTENER_CLASS_NAME":          conditionString,
+	"SERVICE_NAME":                                   conditionString,
+	"SERVICE_PORT":                                   conditionInteger,
+	"SERVICE_PUBLIC_DOMAIN_NAME":                     conditionString,
+	"SERVICE_REMOTE_ENDPOINT":                        conditionString,
+	"SERVICE_REMOTE_SERVICE_NAME":                    conditionString,
+	"SERVICE_TAGS":                                   conditionTag,
+	"SERVICE_TECHNOLOGY":                             conditionSimpleTech,
+	"SERVICE_TECHNOLOGY_EDITION":                     conditionString,
+	"SERVICE_TECHNOLOGY_VERSION":                     conditionString,
+	"SERVICE_TOPOLOGY":                               {comparisonTypes: []string{"SERVICE_TOPOLOGY"}},
+	"SERVICE_TYPE":                                   {comparisonTypes: []string{"SERVICE_TYPE"}},
+	"SERVICE_WEB_APPLICATION_ID":                     conditionString,
+	"SERVICE_WEB_CONTEXT_ROOT":                       conditionString,
+	"SERVICE_WEB_SERVER_ENDPOINT":                    conditionString,
+	"SERVICE_WEB_SERVER_NAME":                        conditionString,
+	"SERVICE_WEB_SERVICE_NAME":                       conditionString,
+	"SERVICE_WEB_SERVICE_NAMESPACE":                  conditionString,
+	"VMWARE_DATACENTER_NAME":                         conditionString,
+	"VMWARE_VM_NAME":                                 conditionString,
+	"WEB_APPLICATION_NAME":                           conditionString,
+	"WEB_APPLICATION_NAME_PATTERN":                   conditionString,
+	"WEB_APPLICATION_TAGS":                           conditionTag,
+	"WEB_APPLICATION_TYPE":                           {comparisonTypes: []string{"APPLICATION_TYPE"}},
+}
+
+// conditionKeyTypes are the types of dynamic keys and the typed attribute each one is given by.
+var conditionKeyTypes = map[string]string{
+	"HOST_CUSTOM_METADATA_KEY":        "custom_metadata",
+	"PROCESS_CUSTOM_METADATA_KEY":     "custom_metadata",
+	"PROCESS_PREDEFINED_METADATA_KEY": "predefined_key",
+	"STRING":                          "string_key",
+}
+
+// conditionKeyTypeNames returns the dynamic key types in alphabetical order.
+func conditionKeyTypeNames() []string {
+	names := make([]string, 0, len(conditionKeyTypes))
+	for name := range conditionKeyTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
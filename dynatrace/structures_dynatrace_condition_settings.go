@@ -0,0 +1,276 @@
+package dynatrace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+)
+
+// settingsPropagations maps the propagation types of the config v1 API onto the
+// flags of attribute rules in Settings 2.0.
+var settingsPropagations = map[string]string{
+	"AZURE_TO_PG":                          "azureToPGPropagation",
+	"AZURE_TO_SERVICE":                     "azureToServicePropagation",
+	"CUSTOM_DEVICE_GROUP_TO_CUSTOM_DEVICE": "customDeviceGroupToCustomDevicePropagation",
+	"HOST_TO_PROCESS_GROUP_INSTANCE":       "hostToPGPropagation",
+	"PROCESS_GROUP_TO_HOST":                "pgToHostPropagation",
+	"PROCESS_GROUP_TO_SERVICE":             "pgToServicePropagation",
+	"SERVICE_TO_HOST_LIKE":                 "serviceToHostPropagation",
+	"SERVICE_TO_PROCESS_GROUP_LIKE":        "serviceToPGPropagation",
+}
+
+// expandSettingsAttributeRule returns the attribute rule of a Settings 2.0 rule for
+// the entity type, propagation types and conditions of a config v1 rule. Management
+// zones and auto tags name the list of conditions differently.
+func expandSettingsAttributeRule(entityType string, propagationTypes *[]string, conditions []dynatraceConfigV1.EntityRuleEngineCondition, conditionsProperty string) (map[string]interface{}, error) {
+	rule := map[string]interface{}{
+		"entityType": entityType,
+	}
+
+	if propagationTypes != nil {
+		for _, propagationType := range *propagationTypes {
+			flag, ok := settingsPropagations[propagationType]
+			if !ok {
+				return nil, fmt.Errorf("the propagation type %s can't be stored in Settings 2.0", propagationType)
+			}
+			rule[flag] = true
+		}
+	}
+
+	cs := make([]interface{}, len(conditions))
+	for i, condition := range conditions {
+		c, err := expandSettingsCondition(condition)
+		if err != nil {
+			return nil, fmt.Errorf("condition %d: %s", i, err)
+		}
+		cs[i] = c
+	}
+	rule[conditionsProperty] = cs
+
+	return rule, nil
+}
+
+// flattenSettingsAttributeRule is the inverse of expandSettingsAttributeRule.
+func flattenSettingsAttributeRule(rule map[string]interface{}, conditionsProperty string) (string, *[]string, []dynatraceConfigV1.EntityRuleEngineCondition, error) {
+	entityType, _ := rule["entityType"].(string)
+
+	propagationTypes := []string{}
+	for propagationType, flag := range settingsPropagations {
+		if enabled, _ := rule[flag].(bool); enabled {
+			propagationTypes = append(propagationTypes, propagationType)
+		}
+	}
+	sort.Strings(propagationTypes)
+
+	conditions, _ := rule[conditionsProperty].([]interface{})
+	cs := make([]dynatraceConfigV1.EntityRuleEngineCondition, len(conditions))
+	for i, condition := range conditions {
+		m, _ := condition.(map[string]interface{})
+		c, err := flattenSettingsCondition(m)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("condition %d: %s", i, err)
+		}
+		cs[i] = c
+	}
+
+	return entityType, &propagationTypes, cs, nil
+}
+
+// expandSettingsCondition turns a condition of the config v1 API into an attribute
+// condition of Settings 2.0, where negations are part of the operator and the
+// value is held by a property named after its kind.
+func expandSettingsCondition(condition dynatraceConfigV1.EntityRuleEngineCondition) (map[string]interface{}, error) {
+	c := map[string]interface{}{
+		"key": condition.Key.Attribute,
+	}
+
+	if condition.Key.DynamicKey != nil {
+		switch dynamicKey := (*condition.Key.DynamicKey).(type) {
+		case map[string]interface{}:
+			c["dynamicKey"] = dynamicKey["key"]
+			c["dynamicKeySource"] = dynamicKey["source"]
+		case string:
+			c["dynamicKey"] = dynamicKey
+		}
+	}
+
+	comparisonInfo := condition.ComparisonInfo
+
+	c["operator"] = comparisonInfo.Operator
+	if comparisonInfo.Negate {
+		c["operator"] = "NOT_" + comparisonInfo.Operator
+	}
+
+	t, ok := conditionComparisonTypes[comparisonInfo.Type]
+	if !ok {
+		return nil, fmt.Errorf("the comparison type %s can't be stored in Settings 2.0", comparisonInfo.Type)
+	}
+
+	if comparisonInfo.CaseSensitive != nil && (t.kind == conditionValueString && comparisonInfo.Type != "ENTITY_ID") {
+		c["caseSensitive"] = *comparisonInfo.CaseSensitive
+	}
+
+	if comparisonInfo.Value == nil || *comparisonInfo.Value == nil {
+		return c, nil
+	}
+	value := *comparisonInfo.Value
+
+	switch t.kind {
+	case conditionValueString:
+		if comparisonInfo.Type == "ENTITY_ID" {
+			c["entityId"] = value
+		} else {
+			c["stringValue"] = value
+		}
+	case conditionValueEnum:
+		c["enumValue"] = fmt.Sprint(value)
+	case conditionValueInteger:
+		c["integerValue"] = value
+	case conditionValueTag:
+		tag, _ := value.(map[string]interface{})
+		c["tag"] = formatSettingsTag(tag)
+	case conditionValueTech:
+		tech, _ := value.(map[string]interface{})
+		techType, ok := tech["type"].(string)
+		if !ok || len(techType) == 0 {
+			return nil, fmt.Errorf("technologies given by verbatim_type only can't be stored in Settings 2.0")
+		}
+		c["enumValue"] = techType
+	}
+
+	return c, nil
+}
+
+// flattenSettingsCondition is the inverse of expandSettingsCondition. Settings 2.0
+// doesn't hold the comparison type, it is taken from the catalog of attributes.
+func flattenSettingsCondition(c map[string]interface{}) (dynatraceConfigV1.EntityRuleEngineCondition, error) {
+	var condition dynatraceConfigV1.EntityRuleEngineCondition
+
+	attribute, _ := c["key"].(string)
+	catalog := conditionAttributes[attribute]
+
+	condition.Key.Attribute = attribute
+	if dynamicKey, ok := c["dynamicKey"].(string); ok && len(dynamicKey) != 0 {
+		keyType := catalog.keyType
+		if source, ok := c["dynamicKeySource"].(string); ok && len(source) != 0 {
+			if len(keyType) == 0 {
+				keyType = "PROCESS_CUSTOM_METADATA_KEY"
+			}
+			condition.Key.SetDynamicKey(map[string]interface{}{"source": source, "key": dynamicKey})
+		} else {
+			if len(keyType) == 0 {
+				keyType = "STRING"
+			}
+			condition.Key.SetDynamicKey(dynamicKey)
+		}
+		condition.Key.SetType(keyType)
+	} else {
+		condition.Key.SetType("STATIC")
+	}
+
+	operator, _ := c["operator"].(string)
+	if strings.HasPrefix(operator, "NOT_") {
+		condition.ComparisonInfo.Negate = true
+		operator = strings.TrimPrefix(operator, "NOT_")
+	}
+	condition.ComparisonInfo.Operator = operator
+
+	comparisonType := func(fallback string, kinds ...conditionValueKind) string {
+		for _, name := range catalog.comparisonTypes {
+			for _, kind := range kinds {
+				if conditionComparisonTypes[name].kind == kind && name != "ENTITY_ID" {
+					return name
+				}
+			}
+		}
+		return fallback
+	}
+
+	switch {
+	case c["tag"] != nil:
+		condition.ComparisonInfo.Type = comparisonType("TAG", conditionValueTag)
+		condition.ComparisonInfo.SetValue(parseSettingsTag(fmt.Sprint(c["tag"])))
+	case c["entityId"] != nil:
+		condition.ComparisonInfo.Type = "ENTITY_ID"
+		condition.ComparisonInfo.SetValue(c["entityId"])
+	case c["integerValue"] != nil:
+		condition.ComparisonInfo.Type = comparisonType("INTEGER", conditionValueInteger)
+		condition.ComparisonInfo.SetValue(c["integerValue"])
+	case c["enumValue"] != nil:
+		enumValue := fmt.Sprint(c["enumValue"])
+		condition.ComparisonInfo.Type = comparisonType(settingsEnumComparisonType(enumValue), conditionValueEnum, conditionValueTech)
+		if len(condition.ComparisonInfo.Type) == 0 {
+			return condition, fmt.Errorf("the comparison type of %s %s is unknown", attribute, enumValue)
+		}
+		if conditionComparisonTypes[condition.ComparisonInfo.Type].kind == conditionValueTech {
+			condition.ComparisonInfo.SetValue(map[string]interface{}{"type": enumValue})
+		} else {
+			condition.ComparisonInfo.SetValue(enumValue)
+		}
+	default:
+		fallback := "STRING"
+		if len(catalog.comparisonTypes) != 0 && c["stringValue"] == nil {
+			fallback = catalog.comparisonTypes[0]
+		}
+		condition.ComparisonInfo.Type = comparisonType(fallback, conditionValueString)
+		if c["stringValue"] != nil {
+			condition.ComparisonInfo.SetValue(c["stringValue"])
+		}
+	}
+
+	if caseSensitive, ok := c["caseSensitive"].(bool); ok && operator != "EXISTS" {
+		condition.ComparisonInfo.SetCaseSensitive(caseSensitive)
+	}
+
+	return condition, nil
+}
+
+// settingsEnumComparisonType returns the enum comparison type holding a value, for
+// attributes missing from the catalog. Values held by several types are ambiguous.
+func settingsEnumComparisonType(value string) string {
+	found := ""
+	for _, name := range conditionComparisonTypeNames() {
+		t := conditionComparisonTypes[name]
+		if t.kind == conditionValueEnum && conditionContains(t.values, value) {
+			if len(found) != 0 {
+				return ""
+			}
+			found = name
+		}
+	}
+	return found
+}
+
+// formatSettingsTag writes a tag comparison value like Settings 2.0 does:
+// [CONTEXT]key:value, without the context for CONTEXTLESS tags.
+func formatSettingsTag(tag map[string]interface{}) string {
+	s := fmt.Sprint(tag["key"])
+	if context, ok := tag["context"].(string); ok && len(context) != 0 && context != "CONTEXTLESS" {
+		s = "[" + context + "]" + s
+	}
+	if value, ok := tag["value"].(string); ok && len(value) != 0 {
+		s += ":" + value
+	}
+	return s
+}
+
+func parseSettingsTag(s string) map[string]interface{} {
+	tag := map[string]interface{}{"context": "CONTEXTLESS"}
+
+	if strings.HasPrefix(s, "[") {
+		if i := strings.Index(s, "]"); i > 0 {
+			tag["context"] = s[1:i]
+			s = s[i+1:]
+		}
+	}
+
+	if i := strings.Index(s, ":"); i >= 0 {
+		tag["value"] = s[i+1:]
+		s = s[:i]
+	}
+	tag["key"] = s
+
+	return tag
+}
@@ -0,0 +1,139 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+)
+
+func testSettingsCondition(attribute string, comparisonType string, operator string, negate bool, value interface{}) dynatraceConfigV1.EntityRuleEngineCondition {
+	var condition dynatraceConfigV1.EntityRuleEngineCondition
+
+	condition.Key.Attribute = attribute
+	condition.Key.SetType("STATIC")
+	condition.ComparisonInfo.Type = comparisonType
+	condition.ComparisonInfo.Operator = operator
+	condition.ComparisonInfo.Negate = negate
+	if value != nil {
+		condition.ComparisonInfo.SetValue(value)
+	}
+
+	return condition
+}
+
+func TestSettingsConditionRoundTrip(t *testing.T) {
+	caseSensitive := testSettingsCondition("HOST_NAME", "STRING", "BEGINS_WITH", true, "prod-")
+	caseSensitive.ComparisonInfo.SetCaseSensitive(false)
+
+	dynamicKey := testSettingsCondition("PROCESS_GROUP_CUSTOM_METADATA", "STRING", "EQUALS", false, "production")
+	dynamicKey.Key.SetType("PROCESS_CUSTOM_METADATA_KEY")
+	dynamicKey.Key.SetDynamicKey(map[string]interface{}{"source": "KUBERNETES", "key": "stage"})
+
+	cases := []struct {
+		Name           string
+		Input          dynatraceConfigV1.EntityRuleEngineCondition
+		ExpectedOutput map[string]interface{}
+	}{
+		{
+			"negated string",
+			caseSensitive,
+			map[string]interface{}{"key": "HOST_NAME", "operator": "NOT_BEGINS_WITH", "caseSensitive": false, "stringValue": "prod-"},
+		},
+		{
+			"tag",
+			testSettingsCondition("SERVICE_TAGS", "TAG", "EQUALS", false, map[string]interface{}{"context": "KUBERNETES", "key": "app", "value": "web"}),
+			map[string]interface{}{"key": "SERVICE_TAGS", "operator": "EQUALS", "tag": "[KUBERNETES]app:web"},
+		},
+		{
+			"technology",
+			testSettingsCondition("HOST_TECHNOLOGY", "SIMPLE_HOST_TECH", "EQUALS", false, map[string]interface{}{"type": "DOCKER"}),
+			map[string]interface{}{"key": "HOST_TECHNOLOGY", "operator": "EQUALS", "enumValue": "DOCKER"},
+		},
+		{
+			"dynamic key",
+			dynamicKey,
+			map[string]interface{}{"key": "PROCESS_GROUP_CUSTOM_METADATA", "dynamicKey": "stage", "dynamicKeySource": "KUBERNETES", "operator": "EQUALS", "stringValue": "production"},
+		},
+		{
+			"exists",
+			testSettingsCondition("HOST_NAME", "STRING", "EXISTS", false, nil),
+			map[string]interface{}{"key": "HOST_NAME", "operator": "EXISTS"},
+		},
+	}
+
+	for _, c := range cases {
+		output, err := expandSettingsCondition(c.Input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		if !reflect.DeepEqual(output, c.ExpectedOutput) {
+			t.Fatalf("%s: unexpected output from expander.\nExpected: %#v\nGiven:    %#v", c.Name, c.ExpectedOutput, output)
+		}
+
+		condition, err := flattenSettingsCondition(output)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		if !reflect.DeepEqual(condition, c.Input) {
+			t.Fatalf("%s: unexpected output from flattener.\nExpected: %#v\nGiven:    %#v", c.Name, c.Input, condition)
+		}
+	}
+}
+
+func TestSettingsAttributeRulePropagation(t *testing.T) {
+	propagationTypes := []string{"SERVICE_TO_HOST_LIKE", "PROCESS_GROUP_TO_HOST"}
+
+	rule, err := expandSettingsAttributeRule("SERVICE", &propagationTypes, nil, "conditions")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"entityType":               "SERVICE",
+		"serviceToHostPropagation": true,
+		"pgToHostPropagation":      true,
+		"conditions":               []interface{}{},
+	}
+	if !reflect.DeepEqual(rule, expected) {
+		t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v", expected, rule)
+	}
+
+	entityType, flattened, _, err := flattenSettingsAttributeRule(rule, "conditions")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if entityType != "SERVICE" || !reflect.DeepEqual(*flattened, []string{"PROCESS_GROUP_TO_HOST", "SERVICE_TO_HOST_LIKE"}) {
+		t.Fatalf("Unexpected output from flattener: %s %v", entityType, *flattened)
+	}
+
+	unknown := []string{"UNKNOWN"}
+	if _, err := expandSettingsAttributeRule("SERVICE", &unknown, nil, "conditions"); err == nil {
+		t.Fatalf("Expected an error for an unknown propagation type")
+	}
+}
+
+func TestParseSettingsTag(t *testing.T) {
+	cases := []struct {
+		Input          string
+		ExpectedOutput map[string]interface{}
+	}{
+		{"app", map[string]interface{}{"context": "CONTEXTLESS", "key": "app"}},
+		{"app:web:v2", map[string]interface{}{"context": "CONTEXTLESS", "key": "app", "value": "web:v2"}},
+		{"[AWS]Name:frontend", map[string]interface{}{"context": "AWS", "key": "Name", "value": "frontend"}},
+	}
+
+	for _, c := range cases {
+		output := parseSettingsTag(c.Input)
+		if !reflect.DeepEqual(output, c.ExpectedOutput) {
+			t.Fatalf("%s: unexpected output.\nExpected: %#v\nGiven:    %#v", c.Input, c.ExpectedOutput, output)
+		}
+
+		if tag := formatSettingsTag(output); tag != c.Input {
+			t.Fatalf("%s: unexpected formatted tag %s", c.Input, tag)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package dynatrace
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandConditionTypedValue(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          map[string]interface{}
+		ExpectedOutput interface{}
+	}{
+		{
+			"enum",
+			map[string]interface{}{
+				"type":         "SERVICE_TYPE",
+				"service_type": "WEB_SERVICE",
+			},
+			"WEB_SERVICE",
+		},
+		{
+			"integer",
+			map[string]interface{}{
+				"type":    "INTEGER",
+				"integer": 443,
+			},
+			443,
+		},
+		{
+			"tag",
+			map[string]interface{}{
+				"type": "TAG",
+				"tag": []interface{}{
+					map[string]interface{}{"context": "CONTEXTLESS", "key": "env", "value": "prod"},
+				},
+			},
+			map[string]interface{}{"context": "CONTEXTLESS", "key": "env", "value": "prod"},
+		},
+		{
+			"technology",
+			map[string]interface{}{
+				"type": "SIMPLE_TECH",
+				"simple_tech": []interface{}{
+					map[string]interface{}{"type": "JAVA", "verbatim_type": ""},
+				},
+			},
+			map[string]interface{}{"type": "JAVA"},
+		},
+		{
+			"json",
+			map[string]interface{}{
+				"type":  "SERVICE_TYPE",
+				"value": "\"WEB_SERVICE\"",
+			},
+			nil,
+		},
+	}
+	for _, tc := range cases {
+		output, _ := expandConditionTypedValue(tc.Input)
+		if !reflect.DeepEqual(output, tc.ExpectedOutput) {
+			t.Fatalf("%s: unexpected output from expander.\nExpected: %#v\nGiven:    %#v", tc.Name, tc.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestFlattenConditionTypedValue(t *testing.T) {
+	c := map[string]interface{}{
+		"type":  "TAG",
+		"value": "{\"context\":\"CONTEXTLESS\",\"key\":\"env\"}",
+	}
+
+	flattenConditionTypedValue(c)
+
+	expected := map[string]interface{}{
+		"type": "TAG",
+		"tag": []interface{}{
+			map[string]interface{}{"context": "CONTEXTLESS", "key": "env", "value": nil},
+		},
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Fatalf("Unexpected output from flattener.\nExpected: %#v\nGiven:    %#v", expected, c)
+	}
+}
+
+func testCondition(key map[string]interface{}, comparisonInfo map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"key":             []interface{}{key},
+		"comparison_info": []interface{}{comparisonInfo},
+	}
+}
+
+func TestValidateCondition(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          map[string]interface{}
+		ExpectedErrors []string
+	}{
+		{
+			"typed",
+			testCondition(map[string]interface{}{"attribute": "SERVICE_TYPE"}, map[string]interface{}{"type": "SERVICE_TYPE", "operator": "EQUALS", "service_type": "WEB_SERVICE"}),
+			nil,
+		},
+		{
+			"json",
+			testCondition(map[string]interface{}{"attribute": "SERVICE_TYPE"}, map[string]interface{}{"type": "SERVICE_TYPE", "operator": "EQUALS", "value": "\"WEB_SERVICE\""}),
+			nil,
+		},
+		{
+			"exists",
+			testCondition(map[string]interface{}{"attribute": "SERVICE_TYPE"}, map[string]interface{}{"type": "SERVICE_TYPE", "operator": "EXISTS", "service_type": "WEB_SERVICE"}),
+			[]string{"rule.0.condition.0.comparison_info.0.service_type: must not be set for the EXISTS operator"},
+		},
+		{
+			"operator",
+			testCondition(map[string]interface{}{"attribute": "SERVICE_TYPE"}, map[string]interface{}{"type": "SERVICE_TYPE", "operator": "CONTAINS", "service_type": "WEB_SERVICE"}),
+			[]string{"rule.0.condition.0.comparison_info.0.operator: CONTAINS is not supported by the SERVICE_TYPE comparison type, use one of EQUALS, EXISTS"},
+		},
+		{
+			"mismatch",
+			testCondition(map[string]interface{}{"attribute": "SERVICE_TYPE"}, map[string]interface{}{"type": "SERVICE_TYPE", "operator": "EQUALS", "os_type": "LINUX"}),
+			[]string{
+				"rule.0.condition.0.comparison_info.0.os_type: doesn't apply to the SERVICE_TYPE comparison type, use service_type",
+			},
+		},
+		{
+			"both forms",
+			testCondition(map[string]interface{}{"attribute": "SERVICE_TYPE"}, map[string]interface{}{"type": "SERVICE_TYPE", "operator": "EQUALS", "value": "\"WEB_SERVICE\"", "service_type": "WEB_SERVICE"}),
+			[]string{"rule.0.condition.0.comparison_info.0.value: only one of value, service_type can be set"},
+		},
+		{
+			"missing",
+			testCondition(map[string]interface{}{"attribute": "SERVICE_NAME"}, map[string]interface{}{"type": "STRING", "operator": "BEGINS_WITH"}),
+			[]string{"rule.0.condition.0.comparison_info.0.string: is required for the BEGINS_WITH operator"},
+		},
+		{
+			"attribute",
+			testCondition(map[string]interface{}{"attribute": "HOST_OS_TYPE"}, map[string]interface{}{"type": "STRING", "operator": "EQUALS", "string": "LINUX"}),
+			[]string{"rule.0.condition.0.comparison_info.0.type: the HOST_OS_TYPE attribute is compared as OS_TYPE, got STRING"},
+		},
+		{
+			"unknown attribute",
+			testCondition(map[string]interface{}{"attribute": "NEW_ATTRIBUTE"}, map[string]interface{}{"type": "STRING", "operator": "EQUALS", "string": "value"}),
+			nil,
+		},
+		{
+			"comparison type",
+			testCondition(map[string]interface{}{"attribute": "NEW_ATTRIBUTE"}, map[string]interface{}{"type": "BOOLEAN", "operator": "EQUALS", "value": "true"}),
+			[]string{"rule.0.condition.0.comparison_info.0.type: BOOLEAN is not a comparison type, use one of " + strings.Join(conditionComparisonTypeNames(), ", ")},
+		},
+		{
+			"custom metadata",
+			testCondition(
+				map[string]interface{}{
+					"attribute": "PROCESS_GROUP_CUSTOM_METADATA",
+					"type":      "PROCESS_CUSTOM_METADATA_KEY",
+					"custom_metadata": []interface{}{
+						map[string]interface{}{"source": "ENVIRONMENT", "key": "team"},
+					},
+				},
+				map[string]interface{}{"type": "STRING", "operator": "EQUALS", "string": "payments"},
+			),
+			nil,
+		},
+		{
+			"key type",
+			testCondition(
+				map[string]interface{}{"attribute": "PROCESS_GROUP_PREDEFINED_METADATA", "type": "STRING", "predefined_key": "KUBERNETES_NAMESPACE"},
+				map[string]interface{}{"type": "STRING", "operator": "EQUALS", "string": "payments"},
+			),
+			[]string{
+				"rule.0.condition.0.key.0.type: must be PROCESS_PREDEFINED_METADATA_KEY for the PROCESS_GROUP_PREDEFINED_METADATA attribute",
+				"rule.0.condition.0.key.0.predefined_key: doesn't apply to the STRING key type, use string_key",
+			},
+		},
+		{
+			"static key",
+			testCondition(
+				map[string]interface{}{"attribute": "SERVICE_NAME", "type": "STATIC"},
+				map[string]interface{}{"type": "STRING", "operator": "EQUALS", "string": "payments"},
+			),
+			nil,
+		},
+		{
+			"static key with dynamic key",
+			testCondition(
+				map[string]interface{}{"attribute": "NEW_ATTRIBUTE", "type": "STATIC", "string_key": "team"},
+				map[string]interface{}{"type": "STRING", "operator": "EQUALS", "string": "payments"},
+			),
+			[]string{"rule.0.condition.0.key.0.type: STATIC keys have no string_key, use one of " + strings.Join(conditionKeyTypeNames(), ", ")},
+		},
+		{
+			"static key for a dynamic attribute",
+			testCondition(
+				map[string]interface{}{"attribute": "PROCESS_GROUP_PREDEFINED_METADATA", "type": "STATIC"},
+				map[string]interface{}{"type": "STRING", "operator": "EQUALS", "string": "payments"},
+			),
+			[]string{"rule.0.condition.0.key.0.type: must be PROCESS_PREDEFINED_METADATA_KEY for the PROCESS_GROUP_PREDEFINED_METADATA attribute"},
+		},
+	}
+	for _, tc := range cases {
+		errs := validateCondition(tc.Input, "rule.0.condition.0.")
+		output := make([]string, len(errs))
+		for i, err := range errs {
+			output[i] = err.Error()
+		}
+		if len(output) == 0 && len(tc.ExpectedErrors) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(output, tc.ExpectedErrors) {
+			t.Fatalf("%s: unexpected errors.\nExpected: %#v\nGiven:    %#v", tc.Name, tc.ExpectedErrors, output)
+		}
+	}
+}
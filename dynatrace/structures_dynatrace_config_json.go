@@ -0,0 +1,126 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// configJSONEntityPath returns the path of the configuration managed by a
+// dynatrace_config_json resource, relative to the config v1 API base.
+func configJSONEntityPath(d *schema.ResourceData) string {
+	path := d.Get("path").(string)
+	if d.Get("style").(string) == "singleton" {
+		return path
+	}
+	return strings.TrimSuffix(path, "/") + "/" + d.Id()
+}
+
+// configJSONOnDelete returns what happens to the configuration when the resource
+// is destroyed, which depends on the style unless configured.
+func configJSONOnDelete(d *schema.ResourceData) string {
+	if onDelete, ok := d.GetOk("on_delete"); ok {
+		return onDelete.(string)
+	}
+	if d.Get("style").(string) == "singleton" {
+		return "keep"
+	}
+	return "delete"
+}
+
+// configJSONIgnoredPaths returns the paths dropped from a configuration before it
+// is compared: metadata, which the config API adds to every response, the id of
+// entities of collections and the configured ignore_paths.
+func configJSONIgnoredPaths(style string, ignorePaths []interface{}) []string {
+	paths := []string{"metadata"}
+	if style != "singleton" {
+		paths = append(paths, "id")
+	}
+	for _, path := range ignorePaths {
+		paths = append(paths, path.(string))
+	}
+	return paths
+}
+
+// normalizeConfigJSON drops the ignored paths from a configuration and normalizes the remainder.
+func normalizeConfigJSON(contents string, ignoredPaths []string) (string, error) {
+	value, err := decodeConfigJSON(contents)
+	if err != nil {
+		return "", err
+	}
+
+	for _, path := range ignoredPaths {
+		removeJSONPath(value, strings.Split(path, "."))
+	}
+
+	return encodeJSON(value)
+}
+
+// expandConfigJSON returns the request body for a configuration, carrying the id
+// of entities of collections on updates.
+func expandConfigJSON(contents string, id string) ([]byte, error) {
+	value, err := decodeConfigJSON(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(value, "metadata")
+	delete(value, "id")
+
+	if len(id) != 0 {
+		value["id"] = id
+	}
+
+	return json.Marshal(value)
+}
+
+func decodeConfigJSON(contents string) (map[string]interface{}, error) {
+	var value map[string]interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(contents))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("the configuration must be a JSON object")
+	}
+
+	return value, nil
+}
+
+// removeJSONPath removes the value at a path of object keys from a decoded JSON
+// value. A * matches all elements of an array or all keys of an object.
+func removeJSONPath(value interface{}, path []string) {
+	if len(path) == 0 {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if path[0] == "*" {
+			for k := range v {
+				if len(path) == 1 {
+					delete(v, k)
+				} else {
+					removeJSONPath(v[k], path[1:])
+				}
+			}
+			return
+		}
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		removeJSONPath(v[path[0]], path[1:])
+	case []interface{}:
+		if path[0] != "*" {
+			return
+		}
+		for _, element := range v {
+			removeJSONPath(element, path[1:])
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package dynatrace
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNormalizeConfigJSON(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          string
+		IgnoredPaths   []string
+		ExpectedOutput string
+	}{
+		{
+			"server fields",
+			`{"metadata":{"clusterVersion":"1.220.0"},"id":"1","name":"Default"}`,
+			configJSONIgnoredPaths("collection", nil),
+			`{"name":"Default"}`,
+		},
+		{
+			"singletons keep the id",
+			`{"metadata":{},"id":"1","enabled":true}`,
+			configJSONIgnoredPaths("singleton", nil),
+			`{"enabled":true,"id":"1"}`,
+		},
+		{
+			"wildcards",
+			`{"rules":[{"id":"a","key":"x"},{"id":"b","key":"y"}],"tags":{"a":{"id":1,"v":2}}}`,
+			configJSONIgnoredPaths("singleton", []interface{}{"rules.*.id", "tags.*.id"}),
+			`{"rules":[{"key":"x"},{"key":"y"}],"tags":{"a":{"v":2}}}`,
+		},
+		{
+			"missing paths",
+			`{"threshold":1.50}`,
+			[]string{"rules.*.id", "threshold.value", "other"},
+			`{"threshold":1.50}`,
+		},
+	}
+
+	for _, c := range cases {
+		output, err := normalizeConfigJSON(c.Input, c.IgnoredPaths)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		if output != c.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %s\nGiven:    %s", c.Name, c.ExpectedOutput, output)
+		}
+	}
+
+	if _, err := normalizeConfigJSON(`[]`, nil); err == nil {
+		t.Fatalf("Expected an error for a configuration that isn't an object")
+	}
+}
+
+func TestResourceDynatraceConfigJSONImport(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Input         string
+		ExpectedPath  string
+		ExpectedStyle string
+		ExpectedID    string
+	}{
+		{"singleton", "/anomalyDetection/applications", "/anomalyDetection/applications", "singleton", "/anomalyDetection/applications"},
+		{"collection", "/alertingProfiles,c01a2b3c", "/alertingProfiles", "collection", "c01a2b3c"},
+	}
+
+	for _, c := range cases {
+		d := resourceDynatraceConfigJSON().Data(nil)
+		d.SetId(c.Input)
+
+		if _, err := resourceDynatraceConfigJSONImport(context.Background(), d, nil); err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		if d.Get("path") != c.ExpectedPath || d.Get("style") != c.ExpectedStyle || d.Id() != c.ExpectedID {
+			t.Fatalf("%s: unexpected import: path %v, style %v, id %s", c.Name, d.Get("path"), d.Get("style"), d.Id())
+		}
+	}
+
+	for _, id := range []string{"alertingProfiles", "/alertingProfiles,"} {
+		d := resourceDynatraceConfigJSON().Data(nil)
+		d.SetId(id)
+
+		if _, err := resourceDynatraceConfigJSONImport(context.Background(), d, nil); err == nil {
+			t.Fatalf("%s: expected an error", id)
+		}
+	}
+}
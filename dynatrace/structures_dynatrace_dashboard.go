@@ -3,39 +3,170 @@ package dynatrace
 import (
 	"encoding/json"
 	"log"
+	"reflect"
+	"strings"
 
 	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func expandDashboard(d *schema.ResourceData) (*dynatraceConfigV1.Dashboard, error) {
+// dashboardConfig is the Dashboard of the config API, with tiles that carry the
+// configuration of every tile type.
+type dashboardConfig struct {
+	Metadata          *dynatraceConfigV1.ConfigurationMetadata `json:"metadata,omitempty"`
+	Id                *string                                  `json:"id,omitempty"`
+	DashboardMetadata dynatraceConfigV1.DashboardMetadata      `json:"dashboardMetadata"`
+	Tiles             []dashboardTile                          `json:"tiles"`
+}
+
+// dashboardTile extends the generated Tile with the fields of the tile types it
+// doesn't model, such as data explorer, image and header tiles. Without them these
+// tiles lose their configuration on a round trip through the API. Fields of tile
+// types the provider doesn't know of are carried as they are in Unknowns.
+type dashboardTile struct {
+	dynatraceConfigV1.Tile
+	dashboardTileExtension
+}
+
+type dashboardTileExtension struct {
+	NameSize              *string         `json:"nameSize,omitempty"`
+	IsAutoRefreshDisabled *bool           `json:"isAutoRefreshDisabled,omitempty"`
+	Queries               json.RawMessage `json:"queries,omitempty"`
+	VisualConfig          json.RawMessage `json:"visualConfig,omitempty"`
+	QueriesSettings       json.RawMessage `json:"queriesSettings,omitempty"`
+	MetricExpressions     *[]string       `json:"metricExpressions,omitempty"`
+	Image                 *string         `json:"image,omitempty"`
+
+	Unknowns map[string]json.RawMessage `json:"-"`
+}
+
+func (t dashboardTile) MarshalJSON() ([]byte, error) {
+	fields, err := jsonObjectFields(t.Tile, t.dashboardTileExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range t.Unknowns {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
 
-	var dtDashboard dynatraceConfigV1.Dashboard
+	return json.Marshal(fields)
+}
+
+func (t *dashboardTile) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &t.Tile); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, &t.dashboardTileExtension); err != nil {
+		return err
+	}
+
+	unknowns, err := unknownJSONFields(b, t.Tile, t.dashboardTileExtension)
+	if err != nil {
+		return err
+	}
+	if len(unknowns) != 0 {
+		t.Unknowns = unknowns
+	}
+
+	return nil
+}
+
+// jsonObjectFields marshals a generated struct and the structs extending it into
+// the fields of one JSON object, later values replacing the fields of earlier ones.
+// Types that embed a generated struct need it for their MarshalJSON, the
+// MarshalJSON of the generated struct would otherwise be promoted and drop the
+// fields of the extension.
+func jsonObjectFields(values ...interface{}) (map[string]json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+
+	for _, value := range values {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		var valueFields map[string]json.RawMessage
+		if err := json.Unmarshal(b, &valueFields); err != nil {
+			return nil, err
+		}
+
+		for k, v := range valueFields {
+			fields[k] = v
+		}
+	}
+
+	return fields, nil
+}
+
+// unknownJSONFields returns the fields of a JSON object that none of the structs
+// in known has a field for.
+func unknownJSONFields(b []byte, known ...interface{}) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	for _, k := range known {
+		t := reflect.TypeOf(k)
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			delete(fields, name)
+		}
+	}
+
+	return fields, nil
+}
+
+func expandDashboard(d *schema.ResourceData) (*dashboardConfig, error) {
+
+	var dtDashboard dashboardConfig
 
 	if dashboardMetadata, ok := d.GetOk("dashboard_metadata"); ok {
-		dtDashboard.SetDashboardMetadata(expandDashboardMetadata(dashboardMetadata.([]interface{})))
+		dtDashboard.DashboardMetadata = expandDashboardMetadata(dashboardMetadata.([]interface{}))
 	}
 
 	if tiles, ok := d.GetOk("tile"); ok {
-		dtDashboard.SetTiles(expandDashboardTiles(tiles.([]interface{})))
+		dtDashboard.Tiles = expandDashboardTiles(tiles.([]interface{}))
+
+		bounds, err := expandDashboardLayoutBounds(tiles.([]interface{}), expandDashboardLayout(d.Get("layout").([]interface{})))
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range dtDashboard.Tiles {
+			dtDashboard.Tiles[i].SetBounds(bounds[i])
+		}
 	}
 
 	return &dtDashboard, nil
 
 }
 
-func expandExistingDashboard(d *schema.ResourceData, id string) (*dynatraceConfigV1.Dashboard, error) {
+func expandExistingDashboard(d *schema.ResourceData, id string) (*dashboardConfig, error) {
 
-	var dtDashboard dynatraceConfigV1.Dashboard
+	var dtDashboard dashboardConfig
 
-	dtDashboard.SetId(id)
+	dtDashboard.Id = &id
 
 	if dashboardMetadata, ok := d.GetOk("dashboard_metadata"); ok {
-		dtDashboard.SetDashboardMetadata(expandDashboardMetadata(dashboardMetadata.([]interface{})))
+		dtDashboard.DashboardMetadata = expandDashboardMetadata(dashboardMetadata.([]interface{}))
 	}
 
 	if tiles, ok := d.GetOk("tile"); ok {
-		dtDashboard.SetTiles(expandDashboardTiles(tiles.([]interface{})))
+		dtDashboard.Tiles = expandDashboardTiles(tiles.([]interface{}))
+
+		bounds, err := expandDashboardLayoutBounds(tiles.([]interface{}), expandDashboardLayout(d.Get("layout").([]interface{})))
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range dtDashboard.Tiles {
+			dtDashboard.Tiles[i].SetBounds(bounds[i])
+		}
 	}
 
 	return &dtDashboard, nil
@@ -71,7 +202,7 @@ func expandDashboardMetadata(dashboardMetadata []interface{}) dynatraceConfigV1.
 	}
 
 	if tags, ok := m["tags"]; ok {
-		dtDashboardMetadata.SetTags(expandDashboardTags(tags.([]interface{})))
+		dtDashboardMetadata.SetTags(expandDashboardTags(tags.(*schema.Set).List()))
 	}
 
 	if preset, ok := m["preset"].(bool); ok {
@@ -170,18 +301,18 @@ func expandDashboardManagementZone(managementZone []interface{}) dynatraceConfig
 
 }
 
-func expandDashboardTiles(dashboardTiles []interface{}) []dynatraceConfigV1.Tile {
+func expandDashboardTiles(dashboardTiles []interface{}) []dashboardTile {
 	if len(dashboardTiles) < 1 {
-		return []dynatraceConfigV1.Tile{}
+		return []dashboardTile{}
 	}
 
-	dts := make([]dynatraceConfigV1.Tile, len(dashboardTiles))
+	dts := make([]dashboardTile, len(dashboardTiles))
 
 	for i, tile := range dashboardTiles {
 
 		m := tile.(map[string]interface{})
 
-		var dtDashboardTile dynatraceConfigV1.Tile
+		var dtDashboardTile dashboardTile
 
 		if name, ok := m["name"].(string); ok {
 			dtDashboardTile.SetName(name)
@@ -251,12 +382,68 @@ func expandDashboardTiles(dashboardTiles []interface{}) []dynatraceConfigV1.Tile
 			dtDashboardTile.SetLimit(int32(limit))
 		}
 
+		if nameSize, ok := m["name_size"].(string); ok && len(nameSize) != 0 {
+			dtDashboardTile.NameSize = &nameSize
+		}
+
+		if autoRefreshDisabled, ok := m["auto_refresh_disabled"].(bool); ok && autoRefreshDisabled {
+			dtDashboardTile.IsAutoRefreshDisabled = &autoRefreshDisabled
+		}
+
+		if queries, ok := m["queries"].(string); ok && len(queries) != 0 {
+			dtDashboardTile.Queries = expandTileJSON("queries", queries)
+		}
+
+		if visualConfig, ok := m["visual_config"].(string); ok && len(visualConfig) != 0 {
+			dtDashboardTile.VisualConfig = expandTileJSON("visual config", visualConfig)
+		}
+
+		if queriesSettings, ok := m["queries_settings"].(string); ok && len(queriesSettings) != 0 {
+			dtDashboardTile.QueriesSettings = expandTileJSON("queries settings", queriesSettings)
+		}
+
+		if metricExpressions, ok := m["metric_expressions"].([]interface{}); ok && len(metricExpressions) != 0 {
+			expressions := expandMetricExpressions(metricExpressions)
+			dtDashboardTile.MetricExpressions = &expressions
+		}
+
+		if image, ok := m["image"].(string); ok && len(image) != 0 {
+			dtDashboardTile.Image = &image
+		}
+
+		if unknowns, ok := m["unknowns"].(string); ok && len(unknowns) != 0 {
+			if err := json.Unmarshal([]byte(unknowns), &dtDashboardTile.Unknowns); err != nil {
+				log.Printf("[ERROR] Could not unmarshal tile unknowns %s: %v", unknowns, err)
+			}
+		}
+
 		dts[i] = dtDashboardTile
 	}
 
 	return dts
 }
 
+func expandMetricExpressions(expressions []interface{}) []string {
+	dme := make([]string, len(expressions))
+
+	for i, v := range expressions {
+		dme[i] = v.(string)
+	}
+
+	return dme
+
+}
+
+func expandTileJSON(field string, value string) json.RawMessage {
+	val, err := normalizeJSON(value)
+	if err != nil {
+		log.Printf("[ERROR] Could not unmarshal tile %s %s: %v", field, value, err)
+		return nil
+	}
+
+	return json.RawMessage(val)
+}
+
 func expandTileBounds(bounds []interface{}) dynatraceConfigV1.TileBounds {
 	if len(bounds) == 0 || bounds[0] == nil {
 		return dynatraceConfigV1.TileBounds{}
@@ -596,7 +783,7 @@ func flattenManagementZone(dashboardManagementZone *dynatraceConfigV1.EntityShor
 
 }
 
-func flattenDashboardTilesData(dashboardTiles []dynatraceConfigV1.Tile) []interface{} {
+func flattenDashboardTilesData(dashboardTiles []dashboardTile) []interface{} {
 	if dashboardTiles != nil {
 		dts := make([]interface{}, len(dashboardTiles), len(dashboardTiles))
 
@@ -609,6 +796,7 @@ func flattenDashboardTilesData(dashboardTiles []dynatraceConfigV1.Tile) []interf
 			dt["bounds"] = flattenTileBounds(&dashboardTile.Bounds)
 			dt["tile_filter"] = flattenTileFilter(dashboardTile.TileFilter)
 			dt["assigned_entities"] = flattenAssignedEntities(dashboardTile.AssignedEntities)
+			dt["metric"] = dashboardTile.Metric
 			dt["filter_config"] = flattenFilterConfig(dashboardTile.FilterConfig)
 			dt["chart_visible"] = dashboardTile.ChartVisible
 			dt["markdown"] = dashboardTile.Markdown
@@ -619,6 +807,14 @@ func flattenDashboardTilesData(dashboardTiles []dynatraceConfigV1.Tile) []interf
 			dt["timeframe_shift"] = dashboardTile.TimeFrameShift
 			dt["visualization_config"] = flattenVisualizationConfig(dashboardTile.VisualizationConfig)
 			dt["limit"] = dashboardTile.Limit
+			dt["name_size"] = dashboardTile.NameSize
+			dt["auto_refresh_disabled"] = dashboardTile.IsAutoRefreshDisabled
+			dt["queries"] = flattenTileJSON("queries", dashboardTile.Queries)
+			dt["visual_config"] = flattenTileJSON("visual config", dashboardTile.VisualConfig)
+			dt["queries_settings"] = flattenTileJSON("queries settings", dashboardTile.QueriesSettings)
+			dt["metric_expressions"] = dashboardTile.MetricExpressions
+			dt["image"] = dashboardTile.Image
+			dt["unknowns"] = flattenTileUnknowns(dashboardTile.Unknowns)
 			dts[i] = dt
 
 		}
@@ -767,7 +963,7 @@ func flattenTags(values *[]string) interface{} {
 		dvs[i] = e
 	}
 
-	return &dvs
+	return dvs
 }
 
 func flattenAssignedEntities(values *[]string) *[]string {
@@ -836,3 +1032,29 @@ func flattenResultMetadata(metadata interface{}) interface{} {
 	}
 	return string(json)
 }
+
+func flattenTileJSON(field string, value json.RawMessage) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	json, err := normalizeJSON(string(value))
+	if err != nil {
+		log.Printf("[ERROR] Could not marshal tile %s: %v", field, err)
+		return ""
+	}
+	return json
+}
+
+func flattenTileUnknowns(unknowns map[string]json.RawMessage) string {
+	if len(unknowns) == 0 {
+		return ""
+	}
+
+	value, err := json.Marshal(unknowns)
+	if err != nil {
+		log.Printf("[ERROR] Could not marshal tile unknowns: %v", err)
+		return ""
+	}
+	return flattenTileJSON("unknowns", value)
+}
@@ -0,0 +1,144 @@
+package dynatrace
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// normalizeJSON re-encodes a JSON document with sorted object keys and no
+// insignificant whitespace, so that equivalent documents compare equal.
+func normalizeJSON(contents string) (string, error) {
+	var val interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(contents))
+	decoder.UseNumber()
+	if err := decoder.Decode(&val); err != nil {
+		return "", err
+	}
+
+	return encodeJSON(val)
+}
+
+// normalizeJSONState is a StateFunc storing JSON attributes in normalized form, so
+// that they only differ from the value read back from the API in content.
+func normalizeJSONState(val interface{}) string {
+	contents, err := normalizeJSON(val.(string))
+	if err != nil {
+		return val.(string)
+	}
+	return contents
+}
+
+func encodeJSON(val interface{}) (string, error) {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(val); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// decodeDashboardJSON parses a dashboard exported from the Dynatrace UI or returned by the API.
+func decodeDashboardJSON(contents string) (map[string]interface{}, error) {
+	var dashboard map[string]interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(contents))
+	decoder.UseNumber()
+	if err := decoder.Decode(&dashboard); err != nil {
+		return nil, err
+	}
+
+	return dashboard, nil
+}
+
+// normalizeDashboardJSON drops the fields the server assigns to a dashboard (id,
+// metadata and the owner in dashboardMetadata) and normalizes the remainder.
+func normalizeDashboardJSON(contents string) (string, error) {
+	dashboard, err := decodeDashboardJSON(contents)
+	if err != nil {
+		return "", err
+	}
+
+	delete(dashboard, "id")
+	delete(dashboard, "metadata")
+
+	if dashboardMetadata, ok := dashboard["dashboardMetadata"].(map[string]interface{}); ok {
+		delete(dashboardMetadata, "owner")
+	}
+
+	return encodeJSON(dashboard)
+}
+
+// projectDashboardJSON drops the fields of a dashboard read from the API that
+// aren't in the configured contents, such as the defaults the server adds to
+// tiles. Tiles keep their order, so they are matched up by position.
+func projectDashboardJSON(contents string, configured string) (string, error) {
+	dashboard, err := decodeDashboardJSON(contents)
+	if err != nil {
+		return "", err
+	}
+
+	configuredDashboard, err := decodeDashboardJSON(configured)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeJSON(projectJSON(dashboard, configuredDashboard))
+}
+
+// projectJSON drops the properties of objects in value that aren't in configured.
+// Elements of arrays as long as the configured ones are projected one by one, other
+// arrays are taken as they are.
+func projectJSON(value interface{}, configured interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		configuredObject, ok := configured.(map[string]interface{})
+		if !ok {
+			return value
+		}
+
+		projected := make(map[string]interface{}, len(configuredObject))
+		for k, e := range v {
+			if configuredValue, ok := configuredObject[k]; ok {
+				projected[k] = projectJSON(e, configuredValue)
+			}
+		}
+
+		return projected
+	case []interface{}:
+		configuredArray, ok := configured.([]interface{})
+		if !ok || len(configuredArray) != len(v) {
+			return value
+		}
+
+		projected := make([]interface{}, len(v))
+		for i, e := range v {
+			projected[i] = projectJSON(e, configuredArray[i])
+		}
+
+		return projected
+	}
+
+	return value
+}
+
+// expandDashboardJSON returns the request body for a dashboard, carrying the id on updates.
+func expandDashboardJSON(contents string, id string) ([]byte, error) {
+	dashboard, err := decodeDashboardJSON(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(dashboard, "metadata")
+	delete(dashboard, "id")
+
+	if len(id) != 0 {
+		dashboard["id"] = id
+	}
+
+	return json.Marshal(dashboard)
+}
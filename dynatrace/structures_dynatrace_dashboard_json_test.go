@@ -0,0 +1,100 @@
+package dynatrace
+
+import (
+	"testing"
+)
+
+func TestNormalizeDashboardJSON(t *testing.T) {
+	cases := []struct {
+		Input          string
+		ExpectedOutput string
+	}{
+		{
+			`{
+				"metadata": {"configurationVersions": [3], "clusterVersion": "1.220.0"},
+				"id": "0f6a1a7b-ec6f-4a19-a8d6-3d5e0d7a7c4e",
+				"dashboardMetadata": {"owner": "someone@example.com", "shared": false, "name": "Overview"},
+				"tiles": [{"tileType": "MARKDOWN", "markdown": "a < b & c", "bounds": {"top": 0, "left": 38}}]
+			}`,
+			`{"dashboardMetadata":{"name":"Overview","shared":false},"tiles":[{"bounds":{"left":38,"top":0},"markdown":"a < b & c","tileType":"MARKDOWN"}]}`,
+		},
+	}
+	for _, tc := range cases {
+		output, err := normalizeDashboardJSON(tc.Input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if output != tc.ExpectedOutput {
+			t.Fatalf("Unexpected output from normalizer.\nExpected: %s\nGiven:    %s",
+				tc.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestProjectJSON(t *testing.T) {
+	value, _ := decodeSettingsValue(`{"name":"Ops","enabled":true,"severityRules":[{"severityLevel":"ERROR","delayInMinutes":0}],"filter":{"mode":"ALL","tags":[]}}`)
+
+	cases := []struct {
+		Name           string
+		Configured     string
+		ExpectedOutput string
+	}{
+		{
+			"arrays by position",
+			`{"name":"Ops","severityRules":[{"severityLevel":"ERROR"}],"filter":{"mode":"ANY"}}`,
+			`{"filter":{"mode":"ALL"},"name":"Ops","severityRules":[{"severityLevel":"ERROR"}]}`,
+		},
+		{
+			"arrays of another length as they are",
+			`{"name":"Ops","severityRules":[],"filter":{"mode":"ANY"}}`,
+			`{"filter":{"mode":"ALL"},"name":"Ops","severityRules":[{"delayInMinutes":0,"severityLevel":"ERROR"}]}`,
+		},
+	}
+	for _, tc := range cases {
+		configured, _ := decodeSettingsValue(tc.Configured)
+		output, err := encodeJSON(projectJSON(value, configured))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+		if output != tc.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %s\nGiven:    %s", tc.Name, tc.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestProjectDashboardJSON(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          string
+		Configured     string
+		ExpectedOutput string
+	}{
+		{
+			"server defaults",
+			`{"dashboardMetadata":{"name":"Overview","shared":false,"preset":false},"tiles":[{"tileType":"MARKDOWN","markdown":"# Hosts","configured":true,"isAutoRefreshDisabled":false}]}`,
+			`{"dashboardMetadata":{"name":"Overview","shared":false},"tiles":[{"tileType":"MARKDOWN","markdown":"# Hosts"}]}`,
+			`{"dashboardMetadata":{"name":"Overview","shared":false},"tiles":[{"markdown":"# Hosts","tileType":"MARKDOWN"}]}`,
+		},
+		{
+			"changed tile",
+			`{"dashboardMetadata":{"name":"Overview"},"tiles":[{"tileType":"MARKDOWN","markdown":"# Services","configured":true}]}`,
+			`{"dashboardMetadata":{"name":"Overview"},"tiles":[{"tileType":"MARKDOWN","markdown":"# Hosts"}]}`,
+			`{"dashboardMetadata":{"name":"Overview"},"tiles":[{"markdown":"# Services","tileType":"MARKDOWN"}]}`,
+		},
+		{
+			"added tile",
+			`{"dashboardMetadata":{"name":"Overview"},"tiles":[{"tileType":"MARKDOWN","markdown":"# Hosts"},{"tileType":"HEADER","name":"Services","configured":true}]}`,
+			`{"dashboardMetadata":{"name":"Overview"},"tiles":[{"tileType":"MARKDOWN","markdown":"# Hosts"}]}`,
+			`{"dashboardMetadata":{"name":"Overview"},"tiles":[{"markdown":"# Hosts","tileType":"MARKDOWN"},{"configured":true,"name":"Services","tileType":"HEADER"}]}`,
+		},
+	}
+	for _, tc := range cases {
+		output, err := projectDashboardJSON(tc.Input, tc.Configured)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+		if output != tc.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %s\nGiven:    %s", tc.Name, tc.ExpectedOutput, output)
+		}
+	}
+}
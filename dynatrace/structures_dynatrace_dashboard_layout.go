@@ -0,0 +1,171 @@
+package dynatrace
+
+import (
+	"fmt"
+	"sort"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+)
+
+// dashboardGridSize is the size in pixels of a cell of the dashboard grid, tiles
+// are positioned and sized in multiples of it.
+const dashboardGridSize = 38
+
+// dashboardLayout places the tiles with a layout block in rows of cells, one row
+// below the other, starting at the top left corner given by top and left.
+type dashboardLayout struct {
+	Top         int
+	Left        int
+	ColumnWidth int
+	RowHeight   int
+}
+
+func expandDashboardLayout(layout []interface{}) dashboardLayout {
+	dl := dashboardLayout{
+		ColumnWidth: 4 * dashboardGridSize,
+		RowHeight:   4 * dashboardGridSize,
+	}
+
+	if len(layout) == 0 || layout[0] == nil {
+		return dl
+	}
+
+	m := layout[0].(map[string]interface{})
+
+	if top, ok := m["top"].(int); ok {
+		dl.Top = top
+	}
+
+	if left, ok := m["left"].(int); ok {
+		dl.Left = left
+	}
+
+	if columnWidth, ok := m["column_width"].(int); ok && columnWidth != 0 {
+		dl.ColumnWidth = columnWidth
+	}
+
+	if rowHeight, ok := m["row_height"].(int); ok && rowHeight != 0 {
+		dl.RowHeight = rowHeight
+	}
+
+	return dl
+}
+
+type dashboardTileCell struct {
+	tile       int
+	row        int
+	columnSpan int
+	rowSpan    int
+}
+
+// expandDashboardLayoutBounds returns the bounds of every tile, taken from the layout
+// block of the tile if it has one and from its bounds otherwise. Tiles in the same
+// row are placed left to right in the order they are declared, and each row is as
+// high as its highest tile.
+func expandDashboardLayoutBounds(tiles []interface{}, layout dashboardLayout) ([]dynatraceConfigV1.TileBounds, error) {
+	bounds := make([]dynatraceConfigV1.TileBounds, len(tiles))
+	cells := []dashboardTileCell{}
+
+	for i, tile := range tiles {
+		m, ok := tile.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("tile.%d: either bounds or a layout block is required", i)
+		}
+
+		if tileLayout, ok := m["layout"].([]interface{}); ok && len(tileLayout) != 0 && tileLayout[0] != nil {
+			l := tileLayout[0].(map[string]interface{})
+			cells = append(cells, dashboardTileCell{
+				tile:       i,
+				row:        l["row"].(int),
+				columnSpan: l["column_span"].(int),
+				rowSpan:    l["row_span"].(int),
+			})
+			continue
+		}
+
+		tileBounds, ok := m["bounds"].([]interface{})
+		if !ok || len(tileBounds) == 0 || tileBounds[0] == nil {
+			return nil, fmt.Errorf("tile.%d: either bounds or a layout block is required", i)
+		}
+
+		bounds[i] = expandTileBounds(tileBounds)
+	}
+
+	sort.SliceStable(cells, func(i, j int) bool {
+		return cells[i].row < cells[j].row
+	})
+
+	top, left, rowHeight := layout.Top, layout.Left, 0
+	for i, cell := range cells {
+		if i > 0 && cell.row != cells[i-1].row {
+			top, left, rowHeight = top+rowHeight, layout.Left, 0
+		}
+
+		width := cell.columnSpan * layout.ColumnWidth
+		height := cell.rowSpan * layout.RowHeight
+
+		dtb := dynatraceConfigV1.TileBounds{}
+		dtb.SetTop(int32(top))
+		dtb.SetLeft(int32(left))
+		dtb.SetWidth(int32(width))
+		dtb.SetHeight(int32(height))
+		bounds[cell.tile] = dtb
+
+		left += width
+		if height > rowHeight {
+			rowHeight = height
+		}
+	}
+
+	return bounds, nil
+}
+
+// validateDashboardTileBounds reports tiles that are not aligned to the dashboard
+// grid or that overlap another tile.
+func validateDashboardTileBounds(bounds []dynatraceConfigV1.TileBounds) []error {
+	var errs []error
+
+	for i, b := range bounds {
+		for _, v := range []struct {
+			name  string
+			value int32
+		}{
+			{"top", b.GetTop()},
+			{"left", b.GetLeft()},
+			{"width", b.GetWidth()},
+			{"height", b.GetHeight()},
+		} {
+			if v.value < 0 || v.value%dashboardGridSize != 0 {
+				errs = append(errs, fmt.Errorf("tile.%d.bounds.0.%s: must be a non-negative multiple of the %dpx grid, got %d", i, v.name, dashboardGridSize, v.value))
+			}
+		}
+
+		if b.GetWidth() == 0 || b.GetHeight() == 0 {
+			errs = append(errs, fmt.Errorf("tile.%d.bounds: width and height must not be 0", i))
+		}
+	}
+
+	for i := range bounds {
+		for j := i + 1; j < len(bounds); j++ {
+			if dashboardTileBoundsOverlap(bounds[i], bounds[j]) {
+				errs = append(errs, fmt.Errorf("tile.%d: overlaps tile.%d at top %d, left %d", j, i, bounds[j].GetTop(), bounds[j].GetLeft()))
+			}
+		}
+	}
+
+	return errs
+}
+
+func dashboardTileBoundsOverlap(a dynatraceConfigV1.TileBounds, b dynatraceConfigV1.TileBounds) bool {
+	return a.GetLeft() < b.GetLeft()+b.GetWidth() && b.GetLeft() < a.GetLeft()+a.GetWidth() &&
+		a.GetTop() < b.GetTop()+b.GetHeight() && b.GetTop() < a.GetTop()+a.GetHeight()
+}
+
+// validateDashboardGridMultiple checks that a length is a multiple of the dashboard grid.
+func validateDashboardGridMultiple(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(int)
+	if v < 0 || v%dashboardGridSize != 0 {
+		errs = append(errs, fmt.Errorf("%q must be a non-negative multiple of the %dpx grid, got %d", key, dashboardGridSize, v))
+	}
+	return
+}
@@ -0,0 +1,228 @@
+package dynatrace
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testDashboardTileBounds(top, left, width, height int32) dynatraceConfigV1.TileBounds {
+	return dynatraceConfigV1.TileBounds{Top: &top, Left: &left, Width: &width, Height: &height}
+}
+
+func TestExpandDashboardLayoutBounds(t *testing.T) {
+	tiles := []interface{}{
+		map[string]interface{}{
+			"bounds": []interface{}{
+				map[string]interface{}{"top": 0, "left": 0, "width": 608, "height": 38},
+			},
+		},
+		map[string]interface{}{
+			"layout": []interface{}{
+				map[string]interface{}{"row": 2, "column_span": 1, "row_span": 1},
+			},
+		},
+		map[string]interface{}{
+			"layout": []interface{}{
+				map[string]interface{}{"row": 1, "column_span": 2, "row_span": 2},
+			},
+		},
+		map[string]interface{}{
+			"layout": []interface{}{
+				map[string]interface{}{"row": 1, "column_span": 1, "row_span": 1},
+			},
+		},
+	}
+
+	layout := expandDashboardLayout([]interface{}{
+		map[string]interface{}{"top": 38, "left": 0, "column_width": 152, "row_height": 114},
+	})
+
+	output, err := expandDashboardLayoutBounds(tiles, layout)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []dynatraceConfigV1.TileBounds{
+		testDashboardTileBounds(0, 0, 608, 38),
+		testDashboardTileBounds(266, 0, 152, 114),
+		testDashboardTileBounds(38, 0, 304, 228),
+		testDashboardTileBounds(38, 304, 152, 114),
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Fatalf("Unexpected output.\nExpected: %v\nGiven:    %v", expected, output)
+	}
+
+	if errs := validateDashboardTileBounds(output); len(errs) != 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	if _, err := expandDashboardLayoutBounds([]interface{}{map[string]interface{}{}}, layout); err == nil {
+		t.Fatalf("Expected an error for a tile without bounds or layout")
+	}
+}
+
+func TestValidateDashboardTileBounds(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          []dynatraceConfigV1.TileBounds
+		ExpectedErrors []string
+	}{
+		{
+			"adjacent",
+			[]dynatraceConfigV1.TileBounds{
+				testDashboardTileBounds(0, 0, 304, 152),
+				testDashboardTileBounds(0, 304, 304, 152),
+				testDashboardTileBounds(152, 0, 608, 38),
+			},
+			nil,
+		},
+		{
+			"off grid",
+			[]dynatraceConfigV1.TileBounds{
+				testDashboardTileBounds(0, 10, 304, 150),
+			},
+			[]string{
+				"tile.0.bounds.0.left: must be a non-negative multiple of the 38px grid, got 10",
+				"tile.0.bounds.0.height: must be a non-negative multiple of the 38px grid, got 150",
+			},
+		},
+		{
+			"overlapping",
+			[]dynatraceConfigV1.TileBounds{
+				testDashboardTileBounds(0, 0, 304, 152),
+				testDashboardTileBounds(114, 266, 304, 152),
+			},
+			[]string{"tile.1: overlaps tile.0 at top 114, left 266"},
+		},
+	}
+	for _, tc := range cases {
+		errs := validateDashboardTileBounds(tc.Input)
+		output := make([]string, len(errs))
+		for i, err := range errs {
+			output[i] = err.Error()
+		}
+		if len(output) == 0 && len(tc.ExpectedErrors) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(output, tc.ExpectedErrors) {
+			t.Fatalf("%s: unexpected errors.\nExpected: %#v\nGiven:    %#v", tc.Name, tc.ExpectedErrors, output)
+		}
+	}
+}
+
+// TestDashboardCustomizeDiffPlansLayoutBounds checks that the bounds computed from
+// the layout are part of the plan, also for tiles that moved since the last apply.
+func TestDashboardCustomizeDiffLayout(t *testing.T) {
+	testTile := func(name string, row int) map[string]interface{} {
+		return map[string]interface{}{
+			"name":      name,
+			"tile_type": "MARKDOWN",
+			"markdown":  name,
+			"layout": []interface{}{
+				map[string]interface{}{"row": row, "column_span": 1, "row_span": 1},
+			},
+		}
+	}
+
+	boundTile := map[string]interface{}{
+		"name":      "Hosts",
+		"tile_type": "MARKDOWN",
+		"markdown":  "Hosts",
+		"bounds": []interface{}{
+			map[string]interface{}{"top": 0, "left": 0, "width": 152, "height": 152},
+		},
+	}
+
+	// the bounds of tiles with a layout block are computed on apply, not kept in the state
+	state := &terraform.InstanceState{
+		ID: "0a1b2c3d-0000-0000-0000-000000000000",
+		Attributes: map[string]string{
+			"id":                          "0a1b2c3d-0000-0000-0000-000000000000",
+			"dashboard_metadata.#":        "1",
+			"dashboard_metadata.0.name":   "Overview",
+			"dashboard_metadata.0.owner":  "someone@example.com",
+			"tile.#":                      "2",
+			"tile.0.name":                 "Hosts",
+			"tile.0.tile_type":            "MARKDOWN",
+			"tile.0.markdown":             "Hosts",
+			"tile.0.layout.#":             "1",
+			"tile.0.layout.0.row":         "0",
+			"tile.0.layout.0.column_span": "1",
+			"tile.0.layout.0.row_span":    "1",
+			"tile.1.name":                 "Services",
+			"tile.1.tile_type":            "MARKDOWN",
+			"tile.1.markdown":             "Services",
+			"tile.1.layout.#":             "1",
+			"tile.1.layout.0.row":         "1",
+			"tile.1.layout.0.column_span": "1",
+			"tile.1.layout.0.row_span":    "1",
+		},
+	}
+
+	cases := []struct {
+		Name            string
+		Tiles           []interface{}
+		ExpectedChanges []string
+		ExpectedError   string
+	}{
+		{
+			"reordered rows",
+			[]interface{}{testTile("Hosts", 1), testTile("Services", 0)},
+			[]string{"tile.0.layout.0.row", "tile.1.layout.0.row"},
+			"",
+		},
+		{
+			"overlap",
+			[]interface{}{boundTile, testTile("Services", 0)},
+			nil,
+			"tile.1: overlaps tile.0 at top 0, left 0",
+		},
+	}
+
+	for _, tc := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"dashboard_metadata": []interface{}{
+				map[string]interface{}{"name": "Overview", "owner": "someone@example.com"},
+			},
+			"tile": tc.Tiles,
+		})
+
+		diff, err := resourceDynatraceDashboard().Diff(context.Background(), state, config, nil)
+		if len(tc.ExpectedError) != 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("%s: unexpected error.\nExpected: %s\nGiven:    %v", tc.Name, tc.ExpectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+
+		changes := []string{}
+		for key := range diff.Attributes {
+			changes = append(changes, key)
+		}
+		sort.Strings(changes)
+		if !reflect.DeepEqual(changes, tc.ExpectedChanges) {
+			t.Fatalf("%s: unexpected changes.\nExpected: %v\nGiven:    %v", tc.Name, tc.ExpectedChanges, changes)
+		}
+	}
+}
+
+func TestDashboardTileRequired(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"dashboard_metadata": []interface{}{
+			map[string]interface{}{"name": "Overview", "owner": "someone@example.com"},
+		},
+	})
+
+	if diags := resourceDynatraceDashboard().Validate(config); !diags.HasError() {
+		t.Fatalf("Expected a dashboard without tiles to be rejected")
+	}
+}
@@ -0,0 +1,72 @@
+package dynatrace
+
+import (
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func expandDashboardReport(d *schema.ResourceData) (*dynatraceConfigV1.DashboardReport, error) {
+
+	var dtDashboardReport dynatraceConfigV1.DashboardReport
+
+	dtDashboardReport.SetType("DASHBOARD")
+
+	if dashboardID, ok := d.GetOk("dashboard_id"); ok {
+		dtDashboardReport.SetDashboardId(dashboardID.(string))
+	}
+
+	// read with Get so that disabling the report is not mistaken for an unset value
+	dtDashboardReport.SetEnabled(d.Get("enabled").(bool))
+
+	if subscriptions, ok := d.GetOk("subscriptions"); ok {
+		dtDashboardReport.SetSubscriptions(expandDashboardReportSubscriptions(subscriptions.([]interface{})))
+	}
+
+	return &dtDashboardReport, nil
+}
+
+func expandDashboardReportSubscriptions(subscriptions []interface{}) dynatraceConfigV1.DashboardReportSubscription {
+	dtSubscriptions := dynatraceConfigV1.DashboardReportSubscription{}
+
+	dtSubscriptions.SetWEEK([]string{})
+	dtSubscriptions.SetMONTH([]string{})
+
+	if len(subscriptions) == 0 || subscriptions[0] == nil {
+		return dtSubscriptions
+	}
+
+	m := subscriptions[0].(map[string]interface{})
+
+	if week, ok := m["week"].([]interface{}); ok {
+		dtSubscriptions.SetWEEK(expandDashboardReportRecipients(week))
+	}
+
+	if month, ok := m["month"].([]interface{}); ok {
+		dtSubscriptions.SetMONTH(expandDashboardReportRecipients(month))
+	}
+
+	return dtSubscriptions
+}
+
+func expandDashboardReportRecipients(recipients []interface{}) []string {
+	drr := make([]string, len(recipients))
+
+	for i, v := range recipients {
+		drr[i] = v.(string)
+	}
+
+	return drr
+}
+
+func flattenDashboardReportSubscriptions(subscriptions *dynatraceConfigV1.DashboardReportSubscription) []interface{} {
+	if subscriptions == nil {
+		return nil
+	}
+
+	s := make(map[string]interface{})
+
+	s["week"] = subscriptions.GetWEEK()
+	s["month"] = subscriptions.GetMONTH()
+
+	return []interface{}{s}
+}
@@ -0,0 +1,133 @@
+package dynatrace
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dashboardSharing is the sharing configuration of a dashboard, served by the
+// shareSettings endpoint of the config API that the generated client doesn't cover.
+type dashboardSharing struct {
+	Id           string                       `json:"id"`
+	Enabled      bool                         `json:"enabled"`
+	Preset       bool                         `json:"preset,omitempty"`
+	Permissions  []dashboardSharingPermission `json:"permissions"`
+	PublicAccess dashboardSharingPublicAccess `json:"publicAccess"`
+}
+
+type dashboardSharingPermission struct {
+	// USER, GROUP or ALL
+	Type string `json:"type"`
+	// The ID of the user or group, not set for ALL
+	Id *string `json:"id,omitempty"`
+	// VIEW or EDIT
+	Permission string `json:"permission"`
+}
+
+type dashboardSharingPublicAccess struct {
+	ManagementZoneIds []string          `json:"managementZoneIds"`
+	Urls              map[string]string `json:"urls,omitempty"`
+}
+
+func expandDashboardSharing(d *schema.ResourceData, id string) *dashboardSharing {
+
+	dtSharing := dashboardSharing{
+		Id:          id,
+		Permissions: []dashboardSharingPermission{},
+		PublicAccess: dashboardSharingPublicAccess{
+			ManagementZoneIds: []string{},
+		},
+	}
+
+	if enabled, ok := d.GetOk("enabled"); ok {
+		dtSharing.Enabled = enabled.(bool)
+	}
+
+	if permissions, ok := d.GetOk("permission"); ok {
+		dtSharing.Permissions = expandDashboardSharingPermissions(permissions.([]interface{}))
+	}
+
+	if publicAccess, ok := d.GetOk("public_access"); ok {
+		dtSharing.PublicAccess = expandDashboardSharingPublicAccess(publicAccess.([]interface{}))
+	}
+
+	return &dtSharing
+}
+
+func expandDashboardSharingPermissions(permissions []interface{}) []dashboardSharingPermission {
+	dsp := make([]dashboardSharingPermission, len(permissions))
+
+	for i, permission := range permissions {
+
+		m := permission.(map[string]interface{})
+
+		var dtPermission dashboardSharingPermission
+
+		if pType, ok := m["type"].(string); ok {
+			dtPermission.Type = pType
+		}
+
+		if id, ok := m["id"].(string); ok && len(id) != 0 {
+			dtPermission.Id = &id
+		}
+
+		if level, ok := m["permission"].(string); ok {
+			dtPermission.Permission = level
+		}
+
+		dsp[i] = dtPermission
+	}
+
+	return dsp
+}
+
+func expandDashboardSharingPublicAccess(publicAccess []interface{}) dashboardSharingPublicAccess {
+	dpa := dashboardSharingPublicAccess{
+		ManagementZoneIds: []string{},
+	}
+
+	if len(publicAccess) == 0 || publicAccess[0] == nil {
+		return dpa
+	}
+
+	m := publicAccess[0].(map[string]interface{})
+
+	if managementZoneIds, ok := m["management_zone_ids"].([]interface{}); ok {
+		for _, id := range managementZoneIds {
+			dpa.ManagementZoneIds = append(dpa.ManagementZoneIds, id.(string))
+		}
+	}
+
+	return dpa
+}
+
+func flattenDashboardSharingPermissions(permissions []dashboardSharingPermission) []interface{} {
+	if permissions == nil {
+		return make([]interface{}, 0)
+	}
+
+	dsp := make([]interface{}, len(permissions))
+
+	for i, permission := range permissions {
+		p := make(map[string]interface{})
+
+		p["type"] = permission.Type
+		p["id"] = permission.Id
+		p["permission"] = permission.Permission
+		dsp[i] = p
+	}
+
+	return dsp
+}
+
+func flattenDashboardSharingPublicAccess(publicAccess *dashboardSharingPublicAccess) []interface{} {
+	if publicAccess == nil || len(publicAccess.ManagementZoneIds) == 0 {
+		return nil
+	}
+
+	p := make(map[string]interface{})
+
+	p["management_zone_ids"] = publicAccess.ManagementZoneIds
+	p["urls"] = publicAccess.Urls
+
+	return []interface{}{p}
+}
@@ -0,0 +1,117 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestDashboardTilesRoundTrip checks that the tile configuration the generated Tile
+// model doesn't carry survives a flatten and expand.
+func TestDashboardTilesRoundTrip(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Input string
+	}{
+		{
+			"data explorer",
+			`{"bounds":{"height":304,"left":0,"top":0,"width":418},"configured":true,"customName":"Data explorer results","isAutoRefreshDisabled":true,"metricExpressions":["resolution=null&(builtin:host.cpu.usage:splitBy():avg):limit(100):names"],"name":"","queries":[{"enabled":true,"id":"A","metric":"builtin:host.cpu.usage","spaceAggregation":"AVG","splitBy":[],"timeAggregation":"DEFAULT"}],"queriesSettings":{"resolution":""},"tileFilter":{},"tileType":"DATA_EXPLORER","visualConfig":{"global":{"hideLegend":false},"rules":[],"type":"GRAPH_CHART"}}`,
+		},
+		{
+			"header",
+			`{"bounds":{"height":38,"left":0,"top":0,"width":304},"configured":true,"name":"Infrastructure","nameSize":"LARGE","tileFilter":{},"tileType":"HEADER"}`,
+		},
+		{
+			"image",
+			`{"bounds":{"height":152,"left":0,"top":0,"width":152},"configured":true,"image":"data:image/png;base64,iVBORw0KGgo=","name":"Image","tileFilter":{},"tileType":"IMAGE"}`,
+		},
+		{
+			"slo",
+			`{"assignedEntities":["d6e1e4e0-5d74-3a6b-a0e8-0a3d1e3e0cc5"],"bounds":{"height":152,"left":0,"top":0,"width":304},"configured":true,"metric":"METRICS=true;LEGEND=true;PROBLEMS=true;decimals=10;","name":"Service-level objective","tileFilter":{"timeframe":"-1d"},"tileType":"SLO"}`,
+		},
+		{
+			"unknown fields",
+			`{"bounds":{"height":152,"left":0,"top":0,"width":304},"configured":true,"name":"Problems","severities":["AVAILABILITY","ERROR"],"tileFilter":{},"tileType":"NEW_PROBLEMS","view":{"compact":true}}`,
+		},
+	}
+	for _, tc := range cases {
+		var tile dashboardTile
+		if err := json.Unmarshal([]byte(tc.Input), &tile); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+
+		d := schema.TestResourceDataRaw(t, resourceDynatraceDashboard().Schema, map[string]interface{}{})
+		if err := d.Set("tile", flattenDashboardTilesData([]dashboardTile{tile})); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+
+		tiles := expandDashboardTiles(d.Get("tile").([]interface{}))
+
+		expected, err := json.Marshal(tile.dashboardTileExtension)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+
+		body, err := json.Marshal(tiles[0])
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+
+		var sent dashboardTile
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+
+		output, err := json.Marshal(sent.dashboardTileExtension)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+
+		if string(output) != string(expected) {
+			t.Fatalf("%s: unexpected output.\nExpected: %s\nGiven:    %s", tc.Name, expected, output)
+		}
+
+		if expected, output := flattenTileUnknowns(tile.Unknowns), flattenTileUnknowns(sent.Unknowns); output != expected {
+			t.Fatalf("%s: unexpected unknowns.\nExpected: %s\nGiven:    %s", tc.Name, expected, output)
+		}
+
+		if sent.GetMetric() != tile.GetMetric() {
+			t.Fatalf("%s: unexpected metric.\nExpected: %s\nGiven:    %s", tc.Name, tile.GetMetric(), sent.GetMetric())
+		}
+	}
+}
+
+func TestFlattenDashboardData(t *testing.T) {
+	contents := `{"metadata":{"clusterVersion":"1.220.0","configurationVersions":[3]},"id":"0a1b2c3d-0000-0000-0000-000000000000","dashboardMetadata":{"name":"Golden","shared":true,"owner":"someone@example.com","tags":["golden"]},"tiles":[{"name":"Header","tileType":"HEADER","configured":true,"bounds":{"top":0,"left":0,"width":304,"height":38},"tileFilter":{},"nameSize":"SMALL"}]}`
+
+	dashboard := foundDashboard{id: "0a1b2c3d-0000-0000-0000-000000000000", contents: []byte(contents)}
+	if err := json.Unmarshal(dashboard.contents, &dashboard.dashboard); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	data, err := flattenDashboardData(&dashboard)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceDynatraceDashboard().Schema, map[string]interface{}{})
+	for k, v := range data {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("Unexpected error setting %s: %s", k, err)
+		}
+	}
+
+	expected := `{"dashboardMetadata":{"name":"Golden","shared":true,"tags":["golden"]},"tiles":[{"bounds":{"height":38,"left":0,"top":0,"width":304},"configured":true,"name":"Header","nameSize":"SMALL","tileFilter":{},"tileType":"HEADER"}]}`
+	if output := d.Get("contents").(string); output != expected {
+		t.Fatalf("Unexpected contents.\nExpected: %s\nGiven:    %s", expected, output)
+	}
+
+	if output := d.Get("owner").(string); output != "someone@example.com" {
+		t.Fatalf("Unexpected owner: %s", output)
+	}
+
+	if output := d.Get("tile.0.name_size").(string); output != "SMALL" {
+		t.Fatalf("Unexpected tile name size: %s", output)
+	}
+}
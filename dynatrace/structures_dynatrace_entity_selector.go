@@ -0,0 +1,251 @@
+package dynatrace
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// entitySelectorCriterionPattern matches the name of a criterion of an entity
+// selector, like type, entityName.startsWith or toRelationships.isProcessOf.
+var entitySelectorCriterionPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)*`)
+
+// entitySelectorRelationships are the prefixes of criteria that select entities by
+// the entities they are related to, which are given by a nested entity selector.
+var entitySelectorRelationships = []string{"toRelationships", "fromRelationships", "toRelationship", "fromRelationship"}
+
+// validateEntitySelectorRules checks the selectors of the entity_selector_rule
+// blocks against the entity selector grammar. Selectors that are not known yet are
+// skipped.
+func validateEntitySelectorRules(d *schema.ResourceDiff) []error {
+	var errs []error
+
+	rules, _ := d.Get("entity_selector_rule").([]interface{})
+
+	for i, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		attribute := fmt.Sprintf("entity_selector_rule.%d.selector", i)
+		if !d.NewValueKnown(attribute) {
+			continue
+		}
+
+		selector, _ := rule.(map[string]interface{})["selector"].(string)
+		if err := validateEntitySelector(selector); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s", attribute, err))
+		}
+	}
+
+	return errs
+}
+
+// validateEntitySelector checks an entity selector like
+// type(SERVICE),tag("stage:production"),toRelationships.runsOn(type(HOST)). A
+// selector is a comma separated list of criteria, which take values, a nested
+// selector for relationships or a single criterion for not. Values are quoted or
+// not, special characters are escaped by a tilde. Selectors, nested ones included,
+// need a type or an entityId criterion.
+func validateEntitySelector(selector string) error {
+	p := &entitySelectorParser{s: selector}
+
+	if err := p.selector(); err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return p.errorf("unexpected %q", p.s[p.pos])
+	}
+
+	return nil
+}
+
+type entitySelectorParser struct {
+	s   string
+	pos int
+}
+
+func (p *entitySelectorParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, a...), p.pos+1)
+}
+
+func (p *entitySelectorParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// consume skips the character c, or fails if the next character is another one.
+func (p *entitySelectorParser) consume(c byte, context string) error {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return p.errorf("expected %q %s, the selector ends", c, context)
+	}
+	if p.s[p.pos] != c {
+		return p.errorf("expected %q %s, found %q", c, context, p.s[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+// selector parses a list of criteria, which must select a type of entities.
+func (p *entitySelectorParser) selector() error {
+	start := p.pos
+	types := 0
+	entityIDs := 0
+
+	for {
+		name, err := p.criterion()
+		if err != nil {
+			return err
+		}
+
+		switch name {
+		case "type":
+			types++
+		case "entityId":
+			entityIDs++
+		}
+
+		p.skipSpace()
+		if p.pos >= len(p.s) || p.s[p.pos] != ',' {
+			break
+		}
+		p.pos++
+	}
+
+	if types > 1 {
+		return fmt.Errorf("the selector starting at position %d has more than one type criterion", start+1)
+	}
+	if types == 0 && entityIDs == 0 {
+		return fmt.Errorf("the selector starting at position %d needs a type or an entityId criterion", start+1)
+	}
+
+	return nil
+}
+
+// criterion parses a criterion and returns its name.
+func (p *entitySelectorParser) criterion() (string, error) {
+	p.skipSpace()
+
+	name := entitySelectorCriterionPattern.FindString(p.s[p.pos:])
+	if len(name) == 0 {
+		if p.pos >= len(p.s) {
+			return "", p.errorf("expected a criterion, the selector ends")
+		}
+		return "", p.errorf("expected a criterion, found %q", p.s[p.pos])
+	}
+	p.pos += len(name)
+
+	if err := p.consume('(', "after "+name); err != nil {
+		return "", err
+	}
+
+	segments := strings.Split(name, ".")
+
+	switch {
+	case name == "not":
+		if _, err := p.criterion(); err != nil {
+			return "", err
+		}
+	case conditionContains(entitySelectorRelationships, segments[0]):
+		if len(segments) != 2 {
+			return "", fmt.Errorf("%s is not a relationship, expected %s.<relationship>", name, segments[0])
+		}
+		if err := p.selector(); err != nil {
+			return "", err
+		}
+	default:
+		values, err := p.values(name)
+		if err != nil {
+			return "", err
+		}
+		if name == "type" && values != 1 {
+			return "", fmt.Errorf("type takes a single entity type, found %d", values)
+		}
+	}
+
+	if err := p.consume(')', "to close "+name); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// values parses the comma separated values of a criterion and returns how many
+// there are.
+func (p *entitySelectorParser) values(name string) (int, error) {
+	count := 0
+
+	for {
+		if err := p.value(name); err != nil {
+			return 0, err
+		}
+		count++
+
+		p.skipSpace()
+		if p.pos >= len(p.s) || p.s[p.pos] != ',' {
+			return count, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *entitySelectorParser) value(name string) error {
+	p.skipSpace()
+
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		start := p.pos
+		p.pos++
+		for {
+			if p.pos >= len(p.s) {
+				p.pos = start
+				return p.errorf("unterminated string")
+			}
+			switch p.s[p.pos] {
+			case '~':
+				if err := p.escape(); err != nil {
+					return err
+				}
+			case '"':
+				p.pos++
+				return nil
+			default:
+				p.pos++
+			}
+		}
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(`(),"`, rune(p.s[p.pos])) {
+		if p.s[p.pos] == '~' {
+			if err := p.escape(); err != nil {
+				return err
+			}
+			continue
+		}
+		p.pos++
+	}
+
+	if p.pos < len(p.s) && (p.s[p.pos] == '(' || p.s[p.pos] == '"') {
+		return p.errorf("unexpected %q in a value of %s, escape it with ~", p.s[p.pos], name)
+	}
+	if len(strings.TrimSpace(p.s[start:p.pos])) == 0 {
+		return p.errorf("expected a value of %s", name)
+	}
+
+	return nil
+}
+
+// escape skips a tilde and the character it escapes.
+func (p *entitySelectorParser) escape() error {
+	if p.pos+1 >= len(p.s) {
+		return p.errorf("expected a character escaped by ~, the selector ends")
+	}
+	p.pos += 2
+	return nil
+}
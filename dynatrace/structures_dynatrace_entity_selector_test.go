@@ -0,0 +1,177 @@
+package dynatrace
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidateEntitySelector(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Input         string
+		ExpectedError string
+	}{
+		{
+			"type",
+			"type(HOST)",
+			"",
+		},
+		{
+			"criteria",
+			`type("SERVICE"), tag("stage:production"), entityName.startsWith("payments")`,
+			"",
+		},
+		{
+			"entity ids",
+			"entityId(HOST-0000000000000001,HOST-0000000000000002)",
+			"",
+		},
+		{
+			"relationship",
+			`type(PROCESS_GROUP_INSTANCE),toRelationships.isPgiOfCgi(type(CLOUD_APPLICATION),namespaceName("production"))`,
+			"",
+		},
+		{
+			"not",
+			`type(HOST),not(toRelationships.runsOn(type(PROCESS_GROUP),tag("legacy")))`,
+			"",
+		},
+		{
+			"escapes",
+			`type(SERVICE),entityName("checkout ~(eu~)"),entityName.in(a~,b,"say ~"hi~"")`,
+			"",
+		},
+		{
+			"empty",
+			"",
+			"expected a criterion, the selector ends at position 1",
+		},
+		{
+			"no type",
+			`tag("stage:production")`,
+			"the selector starting at position 1 needs a type or an entityId criterion",
+		},
+		{
+			"two types",
+			"type(HOST),type(SERVICE)",
+			"the selector starting at position 1 has more than one type criterion",
+		},
+		{
+			"several types",
+			"type(HOST,SERVICE)",
+			"type takes a single entity type, found 2",
+		},
+		{
+			"nested without type",
+			`type(SERVICE),toRelationships.runsOn(entityName("web"))`,
+			"the selector starting at position 38 needs a type or an entityId criterion",
+		},
+		{
+			"relationship name",
+			"type(SERVICE),toRelationships(type(HOST))",
+			"toRelationships is not a relationship, expected toRelationships.<relationship>",
+		},
+		{
+			"unbalanced",
+			"type(SERVICE),tag(stage",
+			`expected ')' to close tag, the selector ends at position 24`,
+		},
+		{
+			"closing",
+			"type(SERVICE))",
+			`unexpected ')' at position 14`,
+		},
+		{
+			"missing parenthesis",
+			"type(SERVICE),healthState",
+			`expected '(' after healthState, the selector ends at position 26`,
+		},
+		{
+			"missing value",
+			"type(SERVICE),tag()",
+			"expected a value of tag at position 19",
+		},
+		{
+			"unterminated string",
+			`type(SERVICE),entityName("web)`,
+			"unterminated string at position 26",
+		},
+		{
+			"unescaped",
+			"type(SERVICE),entityName(web(1))",
+			`unexpected '(' in a value of entityName, escape it with ~ at position 29`,
+		},
+		{
+			"dangling escape",
+			"type(SERVICE),entityName(web~",
+			"expected a character escaped by ~, the selector ends at position 29",
+		},
+		{
+			"criterion",
+			"type(SERVICE),,tag(x)",
+			`expected a criterion, found ',' at position 15`,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateEntitySelector(tc.Input)
+
+		message := ""
+		if err != nil {
+			message = err.Error()
+		}
+
+		if message != tc.ExpectedError {
+			t.Fatalf("%s: unexpected error.\nExpected: %q\nGiven:    %q", tc.Name, tc.ExpectedError, message)
+		}
+	}
+}
+
+// testUnknownValue is how raw configurations hold values that aren't known yet.
+const testUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// TestManagementZoneCustomizeDiffValidatesEntitySelectors checks that entity
+// selectors are validated while the rules, e.g. dynamic blocks over values of other
+// resources, are unknown.
+func TestManagementZoneCustomizeDiffValidatesEntitySelectors(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Selector      string
+		ExpectedError string
+	}{
+		{
+			"valid",
+			"type(SERVICE)",
+			"",
+		},
+		{
+			"invalid",
+			`tag("stage:production")`,
+			"entity_selector_rule.0.selector: the selector starting at position 1 needs a type or an entityId criterion",
+		},
+	}
+
+	for _, tc := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "production",
+			"rule": testUnknownValue,
+			"entity_selector_rule": []interface{}{
+				map[string]interface{}{"selector": tc.Selector},
+			},
+		})
+
+		_, err := resourceDynatraceManagementZone().Diff(context.Background(), nil, config, nil)
+
+		message := ""
+		if err != nil {
+			message = err.Error()
+		}
+
+		if len(tc.ExpectedError) == 0 && len(message) != 0 || !strings.Contains(message, tc.ExpectedError) {
+			t.Fatalf("%s: unexpected error.\nExpected: %q\nGiven:    %q", tc.Name, tc.ExpectedError, message)
+		}
+	}
+}
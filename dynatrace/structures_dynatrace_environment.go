@@ -23,7 +23,7 @@ func expandEnvironment(d *schema.ResourceData) (*dynatraceClusterV2.Environment,
 	}
 
 	if tags, ok := d.GetOk("tags"); ok {
-		dtEnvironment.SetTags(expandEnvironmentTags(tags.([]interface{})))
+		dtEnvironment.SetTags(expandEnvironmentTags(tags.(*schema.Set).List()))
 	}
 
 	return &dtEnvironment, nil
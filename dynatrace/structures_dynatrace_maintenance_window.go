@@ -1,10 +1,51 @@
 package dynatrace
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// embed the IANA time zone database so that zone_id can be resolved
+	// on hosts without a system zoneinfo installation
+	_ "time/tzdata"
+
 	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// maintenanceWindowTimeLayout is the yyyy-MM-dd HH:mm format used by the schedule start and end.
+const maintenanceWindowTimeLayout = "2006-01-02 15:04"
+
+// maintenanceWindowClockLayout is the HH:mm format used by the recurrence start time.
+const maintenanceWindowClockLayout = "15:04"
+
+var maintenanceWindowUTCOffset = regexp.MustCompile(`^UTC([+-])(\d{2}):(\d{2})$`)
+
+var maintenanceWindowWeekdays = map[string]time.Weekday{
+	"MONDAY":    time.Monday,
+	"TUESDAY":   time.Tuesday,
+	"WEDNESDAY": time.Wednesday,
+	"THURSDAY":  time.Thursday,
+	"FRIDAY":    time.Friday,
+	"SATURDAY":  time.Saturday,
+	"SUNDAY":    time.Sunday,
+}
+
+// maintenanceWindowMaxDurationMinutes limits the duration of a recurrence to its period.
+var maintenanceWindowMaxDurationMinutes = map[string]int32{
+	"DAILY":   24 * 60,
+	"WEEKLY":  7 * 24 * 60,
+	"MONTHLY": 31 * 24 * 60,
+}
+
+// maintenanceWindowOccurrence is a single period in which a maintenance window is active.
+type maintenanceWindowOccurrence struct {
+	Start time.Time
+	End   time.Time
+}
+
 func expandMaintenanceWindow(d *schema.ResourceData) (*dynatraceConfigV1.MaintenanceWindow, error) {
 
 	var dtMainteanceWindow dynatraceConfigV1.MaintenanceWindow
@@ -283,3 +324,302 @@ func flattenMaintenanceWindowRecurrence(maintenanceWindowRecurrence *dynatraceCo
 
 	return []interface{}{m}
 }
+
+// maintenanceWindowLocation resolves a schedule zone_id, given either as an
+// UTC offset (UTC+01:00) or as an IANA time zone (Europe/Vienna).
+func maintenanceWindowLocation(zoneID string) (*time.Location, error) {
+	if len(zoneID) == 0 || zoneID == "UTC" {
+		return time.UTC, nil
+	}
+
+	if m := maintenanceWindowUTCOffset.FindStringSubmatch(zoneID); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(zoneID, offset), nil
+	}
+
+	return time.LoadLocation(zoneID)
+}
+
+// maintenanceWindowOccurrences returns up to limit occurrences of the schedule, in
+// chronological order, that are still active at or begin after the given time.
+func maintenanceWindowOccurrences(schedule *dynatraceConfigV1.Schedule, after time.Time, limit int) ([]maintenanceWindowOccurrence, error) {
+	occurrences := []maintenanceWindowOccurrence{}
+
+	if schedule == nil || limit <= 0 {
+		return occurrences, nil
+	}
+
+	location, err := maintenanceWindowLocation(schedule.ZoneId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid zone_id %q: %v", schedule.ZoneId, err)
+	}
+
+	start, err := time.ParseInLocation(maintenanceWindowTimeLayout, schedule.Start, location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start %q: %v", schedule.Start, err)
+	}
+
+	end, err := time.ParseInLocation(maintenanceWindowTimeLayout, schedule.End, location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end %q: %v", schedule.End, err)
+	}
+
+	recurrenceType := strings.ToUpper(schedule.RecurrenceType)
+
+	if recurrenceType == "ONCE" {
+		if end.After(after) {
+			occurrences = append(occurrences, maintenanceWindowOccurrence{Start: start, End: end})
+		}
+		return occurrences, nil
+	}
+
+	if schedule.Recurrence == nil {
+		return nil, fmt.Errorf("recurrence is required for recurrence type %s", recurrenceType)
+	}
+
+	recurrence := schedule.Recurrence
+
+	startTime, err := time.Parse(maintenanceWindowClockLayout, recurrence.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence start_time %q: %v", recurrence.StartTime, err)
+	}
+
+	duration := time.Duration(recurrence.DurationMinutes) * time.Minute
+
+	var weekday time.Weekday
+	var dayOfMonth int
+
+	switch recurrenceType {
+	case "DAILY":
+	case "WEEKLY":
+		day, ok := maintenanceWindowWeekdays[strings.ToUpper(recurrence.GetDayOfWeek())]
+		if !ok {
+			return nil, fmt.Errorf("invalid recurrence day_of_week %q", recurrence.GetDayOfWeek())
+		}
+		weekday = day
+	case "MONTHLY":
+		dayOfMonth = int(recurrence.GetDayOfMonth())
+		if dayOfMonth < 1 || dayOfMonth > 31 {
+			return nil, fmt.Errorf("invalid recurrence day_of_month %d", dayOfMonth)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported recurrence type %q", schedule.RecurrenceType)
+	}
+
+	// occurrences that began before the given time may still be running,
+	// so the scan starts one duration earlier than the time itself
+	from := after.In(location).Add(-duration)
+	if from.Before(start) {
+		from = start
+	}
+
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location); !day.After(end); day = day.AddDate(0, 0, 1) {
+		switch recurrenceType {
+		case "WEEKLY":
+			if day.Weekday() != weekday {
+				continue
+			}
+		case "MONTHLY":
+			// days beyond the end of a month fall on its last day
+			lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, location).Day()
+			if day.Day() != dayOfMonth && !(day.Day() == lastDay && dayOfMonth > lastDay) {
+				continue
+			}
+		}
+
+		occurrenceStart := time.Date(day.Year(), day.Month(), day.Day(), startTime.Hour(), startTime.Minute(), 0, 0, location)
+		if occurrenceStart.Before(start) {
+			continue
+		}
+		if !occurrenceStart.Before(end) {
+			break
+		}
+
+		occurrenceEnd := occurrenceStart.Add(duration)
+		if occurrenceEnd.After(end) {
+			occurrenceEnd = end
+		}
+
+		if occurrenceEnd.After(after) {
+			occurrences = append(occurrences, maintenanceWindowOccurrence{Start: occurrenceStart, End: occurrenceEnd})
+			if len(occurrences) == limit {
+				break
+			}
+		}
+	}
+
+	return occurrences, nil
+}
+
+// maintenanceWindowActiveAt reports whether the schedule has an occurrence that includes the given time.
+func maintenanceWindowActiveAt(schedule *dynatraceConfigV1.Schedule, at time.Time) (bool, error) {
+	occurrences, err := maintenanceWindowOccurrences(schedule, at, 1)
+	if err != nil {
+		return false, err
+	}
+
+	return len(occurrences) > 0 && !occurrences[0].Start.After(at), nil
+}
+
+// validateMaintenanceWindowTime checks a schedule start or end for the yyyy-MM-dd HH:mm format.
+func validateMaintenanceWindowTime(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := time.Parse(maintenanceWindowTimeLayout, v); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a date and time in yyyy-MM-dd HH:mm format, for example \"2021-06-30 22:00\", got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
+// validateMaintenanceWindowClock checks a recurrence start time for the HH:mm format.
+func validateMaintenanceWindowClock(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := time.Parse(maintenanceWindowClockLayout, v); err != nil || len(v) != len(maintenanceWindowClockLayout) {
+		return nil, []error{fmt.Errorf("expected %s to be a time of day in HH:mm format, for example \"22:00\", got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
+// validateMaintenanceWindowZone checks a zone_id for an UTC offset or a known IANA time zone.
+func validateMaintenanceWindowZone(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := maintenanceWindowLocation(v); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be an IANA time zone such as \"Europe/Vienna\" or an UTC offset such as \"UTC+01:00\", got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
+// validateMaintenanceWindowSchedule checks the fields of a schedule against each other.
+// Every error is prefixed with the path of the offending attribute.
+func validateMaintenanceWindowSchedule(schedule *dynatraceConfigV1.Schedule) []error {
+	var errs []error
+
+	location, err := maintenanceWindowLocation(schedule.ZoneId)
+	if err != nil {
+		// reported by the zone_id validation already
+		return errs
+	}
+
+	start, startErr := time.ParseInLocation(maintenanceWindowTimeLayout, schedule.Start, location)
+	end, endErr := time.ParseInLocation(maintenanceWindowTimeLayout, schedule.End, location)
+
+	if startErr == nil && endErr == nil && !end.After(start) {
+		errs = append(errs, fmt.Errorf("schedule.0.end: %q must be after start %q", schedule.End, schedule.Start))
+	}
+
+	recurrenceType := schedule.RecurrenceType
+	if recurrenceType == "ONCE" {
+		return errs
+	}
+
+	maxDuration, ok := maintenanceWindowMaxDurationMinutes[recurrenceType]
+	if !ok {
+		// reported by the recurrence_type validation already
+		return errs
+	}
+
+	recurrence := schedule.Recurrence
+	if recurrence == nil {
+		errs = append(errs, fmt.Errorf("schedule.0.recurrence: a recurrence block with start_time and duration_minutes is required when recurrence_type is %s", recurrenceType))
+		return errs
+	}
+
+	if recurrenceType == "WEEKLY" && len(recurrence.GetDayOfWeek()) == 0 {
+		errs = append(errs, fmt.Errorf("schedule.0.recurrence.0.day_of_week: is required when recurrence_type is WEEKLY, for example \"MONDAY\""))
+	}
+
+	if recurrenceType == "MONTHLY" && recurrence.GetDayOfMonth() == 0 {
+		errs = append(errs, fmt.Errorf("schedule.0.recurrence.0.day_of_month: is required when recurrence_type is MONTHLY, a value between 1 and 31"))
+	}
+
+	if recurrence.DurationMinutes < 1 || recurrence.DurationMinutes > maxDuration {
+		errs = append(errs, fmt.Errorf("schedule.0.recurrence.0.duration_minutes: must be between 1 and %d for a %s recurrence, got %d", maxDuration, recurrenceType, recurrence.DurationMinutes))
+	}
+
+	if len(errs) == 0 && startErr == nil && endErr == nil {
+		occurrences, err := maintenanceWindowOccurrences(schedule, start, 1)
+		if err == nil && len(occurrences) == 0 {
+			errs = append(errs, fmt.Errorf("schedule.0.recurrence: the %s recurrence never occurs between start %q and end %q", recurrenceType, schedule.Start, schedule.End))
+		}
+	}
+
+	return errs
+}
+
+// maintenanceWindowDefaultOccurrences is the default of next_occurrences_count.
+const maintenanceWindowDefaultOccurrences = 5
+
+// setMaintenanceWindowOccurrences computes the current and upcoming occurrences of the schedule
+// into the next_start, next_end, next_occurrences and active_now attributes.
+func setMaintenanceWindowOccurrences(d *schema.ResourceData, schedule *dynatraceConfigV1.Schedule, now time.Time) error {
+	// defaults are not applied to imported resources, whose state misses next_occurrences_count
+	count := maintenanceWindowDefaultOccurrences
+	if v, ok := d.GetOkExists("next_occurrences_count"); ok {
+		count = v.(int)
+	}
+	d.Set("next_occurrences_count", count)
+
+	limit := count
+	if limit < 1 {
+		limit = 1
+	}
+
+	occurrences, err := maintenanceWindowOccurrences(schedule, now, limit)
+	if err != nil {
+		occurrences = []maintenanceWindowOccurrence{}
+	}
+
+	nextStart, nextEnd, activeNow := "", "", false
+	if len(occurrences) > 0 {
+		nextStart = occurrences[0].Start.Format(time.RFC3339)
+		nextEnd = occurrences[0].End.Format(time.RFC3339)
+		activeNow = !occurrences[0].Start.After(now)
+	}
+
+	d.Set("next_start", nextStart)
+	d.Set("next_end", nextEnd)
+	d.Set("active_now", activeNow)
+
+	if len(occurrences) > count {
+		occurrences = occurrences[:count]
+	}
+
+	if setErr := d.Set("next_occurrences", flattenMaintenanceWindowOccurrences(occurrences)); setErr != nil {
+		return setErr
+	}
+
+	return err
+}
+
+func flattenMaintenanceWindowOccurrences(occurrences []maintenanceWindowOccurrence) []interface{} {
+	mwo := make([]interface{}, len(occurrences))
+
+	for i, occurrence := range occurrences {
+		mo := make(map[string]interface{})
+
+		mo["start"] = occurrence.Start.Format(time.RFC3339)
+		mo["end"] = occurrence.End.Format(time.RFC3339)
+		mwo[i] = mo
+	}
+
+	return mwo
+}
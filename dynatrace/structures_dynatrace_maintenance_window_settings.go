@@ -0,0 +1,384 @@
+package dynatrace
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maintenanceWindowSettingsRecurrences names the property holding the recurrence of
+// each recurrence type in builtin:alerting.maintenance-window settings objects.
+var maintenanceWindowSettingsRecurrences = map[string]string{
+	"ONCE":    "onceRecurrence",
+	"DAILY":   "dailyRecurrence",
+	"WEEKLY":  "weeklyRecurrence",
+	"MONTHLY": "monthlyRecurrence",
+}
+
+const (
+	maintenanceWindowSettingsTimeLayout  = "2006-01-02T15:04:05"
+	maintenanceWindowSettingsDateLayout  = "2006-01-02"
+	maintenanceWindowSettingsClockLayout = "15:04:05"
+)
+
+// expandMaintenanceWindowSettings returns the value of the
+// builtin:alerting.maintenance-window settings object for a maintenance window.
+func expandMaintenanceWindowSettings(d *schema.ResourceData) (map[string]interface{}, error) {
+	mw, err := expandMaintenanceWindow(d)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := expandMaintenanceWindowSettingsSchedule(&mw.Schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"enabled": true,
+		"generalProperties": map[string]interface{}{
+			"name":                             mw.Name,
+			"description":                      mw.Description,
+			"maintenanceType":                  strings.ToUpper(mw.Type),
+			"suppression":                      strings.ToUpper(mw.Suppression),
+			"disableSyntheticMonitorExecution": false,
+		},
+		"schedule": schedule,
+		"filters":  expandMaintenanceWindowSettingsFilters(mw.Scope),
+	}, nil
+}
+
+// expandMaintenanceWindowSettingsFilters turns a scope into filters, which match
+// entities with all of their tags. Matches of entities with any of several tags
+// become one filter per tag.
+func expandMaintenanceWindowSettingsFilters(scope *dynatraceConfigV1.Scope) []interface{} {
+	filters := []interface{}{}
+
+	if scope == nil {
+		return filters
+	}
+
+	for _, entity := range scope.Entities {
+		filters = append(filters, map[string]interface{}{
+			"entityId":        entity,
+			"entityTags":      []interface{}{},
+			"managementZones": []interface{}{},
+		})
+	}
+
+	for _, match := range scope.Matches {
+		managementZones := []interface{}{}
+		if match.MzId != nil && len(*match.MzId) != 0 {
+			managementZones = append(managementZones, *match.MzId)
+		}
+
+		tags := []interface{}{}
+		for _, tag := range match.Tags {
+			tags = append(tags, formatSettingsTag(map[string]interface{}{"context": tag.Context, "key": tag.Key, "value": tag.GetValue()}))
+		}
+
+		if match.GetTagCombination() == "AND" || len(tags) <= 1 {
+			filters = append(filters, map[string]interface{}{
+				"entityType":      match.GetType(),
+				"entityTags":      tags,
+				"managementZones": managementZones,
+			})
+			continue
+		}
+
+		for _, tag := range tags {
+			filters = append(filters, map[string]interface{}{
+				"entityType":      match.GetType(),
+				"entityTags":      []interface{}{tag},
+				"managementZones": managementZones,
+			})
+		}
+	}
+
+	return filters
+}
+
+func expandMaintenanceWindowSettingsSchedule(schedule *dynatraceConfigV1.Schedule) (map[string]interface{}, error) {
+	recurrenceType := strings.ToUpper(schedule.RecurrenceType)
+
+	property, ok := maintenanceWindowSettingsRecurrences[recurrenceType]
+	if !ok {
+		return nil, fmt.Errorf("schedule.0.recurrence_type: %s can't be stored in Settings 2.0", schedule.RecurrenceType)
+	}
+
+	start, err := time.Parse(maintenanceWindowTimeLayout, schedule.Start)
+	if err != nil {
+		return nil, fmt.Errorf("schedule.0.start: %s", err)
+	}
+	end, err := time.Parse(maintenanceWindowTimeLayout, schedule.End)
+	if err != nil {
+		return nil, fmt.Errorf("schedule.0.end: %s", err)
+	}
+
+	if recurrenceType == "ONCE" {
+		return map[string]interface{}{
+			"scheduleType": recurrenceType,
+			property: map[string]interface{}{
+				"startTime": start.Format(maintenanceWindowSettingsTimeLayout),
+				"endTime":   end.Format(maintenanceWindowSettingsTimeLayout),
+				"timeZone":  schedule.ZoneId,
+			},
+		}, nil
+	}
+
+	if schedule.Recurrence == nil {
+		return nil, fmt.Errorf("schedule.0.recurrence: is required when recurrence_type is %s", recurrenceType)
+	}
+	recurrence := schedule.Recurrence
+
+	// Settings 2.0 recurrences are time windows within a day, which may wrap past midnight
+	if recurrence.DurationMinutes >= 24*60 {
+		return nil, fmt.Errorf("schedule.0.recurrence.0.duration_minutes: Settings 2.0 limits recurrences to less than a day, got %d", recurrence.DurationMinutes)
+	}
+
+	startTime, err := time.Parse(maintenanceWindowClockLayout, recurrence.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("schedule.0.recurrence.0.start_time: %s", err)
+	}
+	endTime := startTime.Add(time.Duration(recurrence.DurationMinutes) * time.Minute)
+
+	settingsRecurrence := map[string]interface{}{
+		"timeWindow": map[string]interface{}{
+			"startTime": startTime.Format(maintenanceWindowSettingsClockLayout),
+			"endTime":   endTime.Format(maintenanceWindowSettingsClockLayout),
+			"timeZone":  schedule.ZoneId,
+		},
+		"recurrenceRange": map[string]interface{}{
+			"scheduleStartDate": start.Format(maintenanceWindowSettingsDateLayout),
+			"scheduleEndDate":   end.Format(maintenanceWindowSettingsDateLayout),
+		},
+	}
+
+	switch recurrenceType {
+	case "WEEKLY":
+		settingsRecurrence["dayOfWeek"] = recurrence.GetDayOfWeek()
+	case "MONTHLY":
+		settingsRecurrence["dayOfMonth"] = recurrence.GetDayOfMonth()
+	}
+
+	return map[string]interface{}{
+		"scheduleType": recurrenceType,
+		property:       settingsRecurrence,
+	}, nil
+}
+
+// flattenMaintenanceWindowSettings turns the value of a
+// builtin:alerting.maintenance-window settings object into a maintenance window
+// of the config v1 API, which the attributes are flattened from. Recurrences are
+// limited to dates in Settings 2.0, the start and end times of the prior schedule
+// are kept while their dates are unchanged, and so are the matches of the prior
+// scope.
+func flattenMaintenanceWindowSettings(value map[string]interface{}, prior *dynatraceConfigV1.Schedule, priorScope *dynatraceConfigV1.Scope) (*dynatraceConfigV1.MaintenanceWindow, error) {
+	mw := dynatraceConfigV1.NewMaintenanceWindowWithDefaults()
+
+	generalProperties, _ := value["generalProperties"].(map[string]interface{})
+	mw.Name, _ = generalProperties["name"].(string)
+	mw.Description, _ = generalProperties["description"].(string)
+	mw.Type, _ = generalProperties["maintenanceType"].(string)
+	mw.Suppression, _ = generalProperties["suppression"].(string)
+
+	settingsSchedule, _ := value["schedule"].(map[string]interface{})
+	schedule, err := flattenMaintenanceWindowSettingsSchedule(settingsSchedule, prior)
+	if err != nil {
+		return nil, err
+	}
+	mw.Schedule = *schedule
+
+	filters, _ := value["filters"].([]interface{})
+	mw.SetScope(flattenMaintenanceWindowSettingsFilters(filters, priorScope))
+
+	return mw, nil
+}
+
+// flattenMaintenanceWindowSettingsFilters is the inverse of
+// expandMaintenanceWindowSettingsFilters. Filters that a match of the prior scope
+// expands to are turned back into that match, so matches of any of several tags
+// are merged again and tag combinations are kept. Other filters become a match
+// each.
+func flattenMaintenanceWindowSettingsFilters(filters []interface{}, prior *dynatraceConfigV1.Scope) dynatraceConfigV1.Scope {
+	scope := dynatraceConfigV1.Scope{Entities: []string{}, Matches: []dynatraceConfigV1.MonitoredEntityFilter{}}
+
+	matchFilters := []interface{}{}
+	for _, filter := range filters {
+		m, _ := filter.(map[string]interface{})
+
+		if entityID, ok := m["entityId"].(string); ok && len(entityID) != 0 {
+			scope.Entities = append(scope.Entities, entityID)
+			continue
+		}
+		matchFilters = append(matchFilters, filter)
+	}
+
+	var priorMatches []dynatraceConfigV1.MonitoredEntityFilter
+	if prior != nil {
+		priorMatches = prior.Matches
+	}
+
+	for i := 0; i < len(matchFilters); {
+		if match, n, ok := findMaintenanceWindowSettingsMatch(matchFilters[i:], priorMatches); ok {
+			scope.Matches = append(scope.Matches, match)
+			i += n
+			continue
+		}
+
+		scope.Matches = append(scope.Matches, flattenMaintenanceWindowSettingsFilter(matchFilters[i]))
+		i++
+	}
+
+	return scope
+}
+
+// findMaintenanceWindowSettingsMatch returns the match of the prior scope that
+// expands to the most of the leading filters, and how many filters it expands to.
+func findMaintenanceWindowSettingsMatch(filters []interface{}, priorMatches []dynatraceConfigV1.MonitoredEntityFilter) (dynatraceConfigV1.MonitoredEntityFilter, int, bool) {
+	var found dynatraceConfigV1.MonitoredEntityFilter
+	count := 0
+
+	for _, match := range priorMatches {
+		expanded := expandMaintenanceWindowSettingsFilters(&dynatraceConfigV1.Scope{Matches: []dynatraceConfigV1.MonitoredEntityFilter{match}})
+		if len(expanded) <= count || len(expanded) > len(filters) {
+			continue
+		}
+
+		equal := true
+		for i := range expanded {
+			if maintenanceWindowSettingsFilterKey(expanded[i]) != maintenanceWindowSettingsFilterKey(filters[i]) {
+				equal = false
+				break
+			}
+		}
+
+		if equal {
+			found = match
+			count = len(expanded)
+		}
+	}
+
+	return found, count, count != 0
+}
+
+// maintenanceWindowSettingsFilterKey identifies a filter by the entities it matches.
+func maintenanceWindowSettingsFilterKey(filter interface{}) string {
+	m, _ := filter.(map[string]interface{})
+	entityType, _ := m["entityType"].(string)
+	return fmt.Sprint(entityType, m["managementZones"], m["entityTags"])
+}
+
+// flattenMaintenanceWindowSettingsFilter turns a filter into a match of all of its tags.
+func flattenMaintenanceWindowSettingsFilter(filter interface{}) dynatraceConfigV1.MonitoredEntityFilter {
+	m, _ := filter.(map[string]interface{})
+
+	var match dynatraceConfigV1.MonitoredEntityFilter
+	if entityType, ok := m["entityType"].(string); ok && len(entityType) != 0 {
+		match.SetType(entityType)
+	}
+
+	managementZones, _ := m["managementZones"].([]interface{})
+	if len(managementZones) != 0 {
+		match.SetMzId(fmt.Sprint(managementZones[0]))
+	}
+
+	tags, _ := m["entityTags"].([]interface{})
+	match.Tags = []dynatraceConfigV1.TagInfo{}
+	for _, tag := range tags {
+		t := parseSettingsTag(fmt.Sprint(tag))
+
+		var tagInfo dynatraceConfigV1.TagInfo
+		tagInfo.Context = t["context"].(string)
+		tagInfo.Key = t["key"].(string)
+		if v, ok := t["value"].(string); ok {
+			tagInfo.SetValue(v)
+		}
+		match.Tags = append(match.Tags, tagInfo)
+	}
+
+	if len(match.Tags) > 1 {
+		match.SetTagCombination("AND")
+	} else {
+		match.SetTagCombination("OR")
+	}
+
+	return match
+}
+
+func flattenMaintenanceWindowSettingsSchedule(settingsSchedule map[string]interface{}, prior *dynatraceConfigV1.Schedule) (*dynatraceConfigV1.Schedule, error) {
+	schedule := dynatraceConfigV1.NewScheduleWithDefaults()
+
+	recurrenceType, _ := settingsSchedule["scheduleType"].(string)
+	schedule.RecurrenceType = recurrenceType
+
+	settingsRecurrence, _ := settingsSchedule[maintenanceWindowSettingsRecurrences[recurrenceType]].(map[string]interface{})
+
+	if recurrenceType == "ONCE" {
+		start, err := time.Parse(maintenanceWindowSettingsTimeLayout, fmt.Sprint(settingsRecurrence["startTime"]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid start time of the schedule: %s", err)
+		}
+		end, err := time.Parse(maintenanceWindowSettingsTimeLayout, fmt.Sprint(settingsRecurrence["endTime"]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid end time of the schedule: %s", err)
+		}
+
+		schedule.Start = start.Format(maintenanceWindowTimeLayout)
+		schedule.End = end.Format(maintenanceWindowTimeLayout)
+		schedule.ZoneId, _ = settingsRecurrence["timeZone"].(string)
+
+		return schedule, nil
+	}
+
+	timeWindow, _ := settingsRecurrence["timeWindow"].(map[string]interface{})
+	recurrenceRange, _ := settingsRecurrence["recurrenceRange"].(map[string]interface{})
+
+	startTime, err := time.Parse(maintenanceWindowSettingsClockLayout, fmt.Sprint(timeWindow["startTime"]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time of the schedule: %s", err)
+	}
+	endTime, err := time.Parse(maintenanceWindowSettingsClockLayout, fmt.Sprint(timeWindow["endTime"]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid end time of the schedule: %s", err)
+	}
+
+	duration := endTime.Sub(startTime)
+	if duration <= 0 {
+		duration += 24 * time.Hour
+	}
+
+	var recurrence dynatraceConfigV1.Recurrence
+	recurrence.StartTime = startTime.Format(maintenanceWindowClockLayout)
+	recurrence.DurationMinutes = int32(duration / time.Minute)
+	switch recurrenceType {
+	case "WEEKLY":
+		if dayOfWeek, ok := settingsRecurrence["dayOfWeek"].(string); ok {
+			recurrence.SetDayOfWeek(dayOfWeek)
+		}
+	case "MONTHLY":
+		recurrence.SetDayOfMonth(int32(settingsInt(settingsRecurrence["dayOfMonth"])))
+	}
+	schedule.SetRecurrence(recurrence)
+
+	schedule.ZoneId, _ = timeWindow["timeZone"].(string)
+
+	startDate, _ := recurrenceRange["scheduleStartDate"].(string)
+	endDate, _ := recurrenceRange["scheduleEndDate"].(string)
+
+	schedule.Start = startDate + " 00:00"
+	schedule.End = endDate + " 00:00"
+	if prior != nil {
+		if strings.HasPrefix(prior.Start, startDate+" ") {
+			schedule.Start = prior.Start
+		}
+		if strings.HasPrefix(prior.End, endDate+" ") {
+			schedule.End = prior.End
+		}
+	}
+
+	return schedule, nil
+}
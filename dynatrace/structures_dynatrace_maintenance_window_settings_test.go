@@ -0,0 +1,159 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+)
+
+func TestMaintenanceWindowSettingsSchedule(t *testing.T) {
+	friday := "FRIDAY"
+
+	cases := []struct {
+		Name           string
+		Input          *dynatraceConfigV1.Schedule
+		ExpectedOutput map[string]interface{}
+	}{
+		{
+			"once",
+			&dynatraceConfigV1.Schedule{RecurrenceType: "ONCE", Start: "2021-06-30 22:00", End: "2021-07-01 02:00", ZoneId: "Europe/Vienna"},
+			map[string]interface{}{
+				"scheduleType": "ONCE",
+				"onceRecurrence": map[string]interface{}{
+					"startTime": "2021-06-30T22:00:00",
+					"endTime":   "2021-07-01T02:00:00",
+					"timeZone":  "Europe/Vienna",
+				},
+			},
+		},
+		{
+			"weekly past midnight",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "WEEKLY",
+				Recurrence:     &dynatraceConfigV1.Recurrence{DayOfWeek: &friday, StartTime: "23:00", DurationMinutes: 120},
+				Start:          "2021-06-01 08:00",
+				End:            "2021-12-31 18:00",
+				ZoneId:         "UTC",
+			},
+			map[string]interface{}{
+				"scheduleType": "WEEKLY",
+				"weeklyRecurrence": map[string]interface{}{
+					"dayOfWeek": "FRIDAY",
+					"timeWindow": map[string]interface{}{
+						"startTime": "23:00:00",
+						"endTime":   "01:00:00",
+						"timeZone":  "UTC",
+					},
+					"recurrenceRange": map[string]interface{}{
+						"scheduleStartDate": "2021-06-01",
+						"scheduleEndDate":   "2021-12-31",
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		output, err := expandMaintenanceWindowSettingsSchedule(c.Input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		if !reflect.DeepEqual(output, c.ExpectedOutput) {
+			t.Fatalf("%s: unexpected output from expander.\nExpected: %#v\nGiven:    %#v", c.Name, c.ExpectedOutput, output)
+		}
+
+		schedule, err := flattenMaintenanceWindowSettingsSchedule(output, c.Input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		if !reflect.DeepEqual(schedule, c.Input) {
+			t.Fatalf("%s: unexpected output from flattener.\nExpected: %#v\nGiven:    %#v", c.Name, c.Input, schedule)
+		}
+	}
+
+	daily := &dynatraceConfigV1.Schedule{
+		RecurrenceType: "DAILY",
+		Recurrence:     &dynatraceConfigV1.Recurrence{StartTime: "22:00", DurationMinutes: 24 * 60},
+		Start:          "2021-06-01 00:00",
+		End:            "2021-12-31 00:00",
+		ZoneId:         "UTC",
+	}
+	if _, err := expandMaintenanceWindowSettingsSchedule(daily); err == nil {
+		t.Fatalf("Expected an error for a recurrence of a day")
+	}
+}
+
+func TestMaintenanceWindowSettingsFilters(t *testing.T) {
+	host, mzID, or, and := "HOST", "-6239538939987181652", "OR", "AND"
+	linux, windows := "Linux", "Windows"
+
+	scope := &dynatraceConfigV1.Scope{
+		Entities: []string{"HOST-0123456789ABCDEF"},
+		Matches: []dynatraceConfigV1.MonitoredEntityFilter{
+			{
+				Type:           &host,
+				MzId:           &mzID,
+				TagCombination: &or,
+				Tags: []dynatraceConfigV1.TagInfo{
+					{Context: "CONTEXTLESS", Key: "os", Value: &linux},
+					{Context: "CONTEXTLESS", Key: "os", Value: &windows},
+				},
+			},
+			{
+				Type:           &host,
+				TagCombination: &and,
+				Tags: []dynatraceConfigV1.TagInfo{
+					{Context: "AWS", Key: "stage"},
+					{Context: "CONTEXTLESS", Key: "critical"},
+				},
+			},
+			{
+				Type:           &host,
+				TagCombination: &or,
+				Tags:           []dynatraceConfigV1.TagInfo{{Context: "CONTEXTLESS", Key: "team"}},
+			},
+			{
+				Type:           &host,
+				TagCombination: &or,
+				Tags:           []dynatraceConfigV1.TagInfo{{Context: "CONTEXTLESS", Key: "owner"}},
+			},
+			{
+				Type:           &host,
+				TagCombination: &and,
+				Tags:           []dynatraceConfigV1.TagInfo{{Context: "CONTEXTLESS", Key: "legacy"}},
+			},
+		},
+	}
+
+	filters := expandMaintenanceWindowSettingsFilters(scope)
+
+	expected := []interface{}{
+		map[string]interface{}{"entityId": "HOST-0123456789ABCDEF", "entityTags": []interface{}{}, "managementZones": []interface{}{}},
+		map[string]interface{}{"entityType": "HOST", "entityTags": []interface{}{"os:Linux"}, "managementZones": []interface{}{mzID}},
+		map[string]interface{}{"entityType": "HOST", "entityTags": []interface{}{"os:Windows"}, "managementZones": []interface{}{mzID}},
+		map[string]interface{}{"entityType": "HOST", "entityTags": []interface{}{"[AWS]stage", "critical"}, "managementZones": []interface{}{}},
+		map[string]interface{}{"entityType": "HOST", "entityTags": []interface{}{"team"}, "managementZones": []interface{}{}},
+		map[string]interface{}{"entityType": "HOST", "entityTags": []interface{}{"owner"}, "managementZones": []interface{}{}},
+		map[string]interface{}{"entityType": "HOST", "entityTags": []interface{}{"legacy"}, "managementZones": []interface{}{}},
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v", expected, filters)
+	}
+
+	flattened := flattenMaintenanceWindowSettingsFilters(filters, scope)
+	if !reflect.DeepEqual(flattenMaintenanceWindowScopeData(&flattened), flattenMaintenanceWindowScopeData(scope)) {
+		t.Fatalf("Unexpected output from flattener.\nExpected: %#v\nGiven:    %#v", flattenMaintenanceWindowScopeData(scope), flattenMaintenanceWindowScopeData(&flattened))
+	}
+
+	// without a prior scope, e.g. on import, every filter becomes a match
+	imported := flattenMaintenanceWindowSettingsFilters(filters, nil)
+	if len(imported.Matches) != 6 || imported.Matches[0].GetTagCombination() != "OR" || imported.Matches[2].GetTagCombination() != "AND" {
+		t.Fatalf("Unexpected output from flattener without a prior scope: %#v", flattenMaintenanceWindowScopeData(&imported))
+	}
+	if !reflect.DeepEqual(expandMaintenanceWindowSettingsFilters(&imported), filters) {
+		t.Fatalf("Unexpected filters of the imported scope.\nExpected: %#v\nGiven:    %#v", filters, expandMaintenanceWindowSettingsFilters(&imported))
+	}
+}
@@ -0,0 +1,308 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestMaintenanceWindowOccurrences(t *testing.T) {
+	friday := "FRIDAY"
+	dayOfMonth := int32(31)
+
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Name           string
+		Input          *dynatraceConfigV1.Schedule
+		After          time.Time
+		Limit          int
+		ExpectedOutput []maintenanceWindowOccurrence
+	}{
+		{
+			"once",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "ONCE",
+				Start:          "2020-10-20 15:38",
+				End:            "2020-10-25 15:38",
+				ZoneId:         "UTC",
+			},
+			time.Date(2020, 10, 21, 0, 0, 0, 0, time.UTC),
+			3,
+			[]maintenanceWindowOccurrence{
+				{
+					Start: time.Date(2020, 10, 20, 15, 38, 0, 0, time.UTC),
+					End:   time.Date(2020, 10, 25, 15, 38, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			"once elapsed",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "ONCE",
+				Start:          "2020-10-20 15:38",
+				End:            "2020-10-25 15:38",
+				ZoneId:         "UTC",
+			},
+			time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			3,
+			[]maintenanceWindowOccurrence{},
+		},
+		{
+			"weekly",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "WEEKLY",
+				Recurrence: &dynatraceConfigV1.Recurrence{
+					DayOfWeek:       &friday,
+					StartTime:       "19:21",
+					DurationMinutes: 60,
+				},
+				Start:  "2025-10-20 15:38",
+				End:    "2025-12-25 15:38",
+				ZoneId: "America/Chicago",
+			},
+			time.Date(2025, 10, 31, 19, 30, 0, 0, chicago),
+			2,
+			[]maintenanceWindowOccurrence{
+				{
+					Start: time.Date(2025, 10, 31, 19, 21, 0, 0, chicago),
+					End:   time.Date(2025, 10, 31, 20, 21, 0, 0, chicago),
+				},
+				{
+					Start: time.Date(2025, 11, 7, 19, 21, 0, 0, chicago),
+					End:   time.Date(2025, 11, 7, 20, 21, 0, 0, chicago),
+				},
+			},
+		},
+		{
+			"monthly on the last day",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "MONTHLY",
+				Recurrence: &dynatraceConfigV1.Recurrence{
+					DayOfMonth:      &dayOfMonth,
+					StartTime:       "23:00",
+					DurationMinutes: 120,
+				},
+				Start:  "2021-01-01 00:00",
+				End:    "2021-12-31 00:00",
+				ZoneId: "UTC+02:00",
+			},
+			time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			2,
+			[]maintenanceWindowOccurrence{
+				{
+					Start: time.Date(2021, 2, 28, 21, 0, 0, 0, time.UTC),
+					End:   time.Date(2021, 2, 28, 23, 0, 0, 0, time.UTC),
+				},
+				{
+					Start: time.Date(2021, 3, 31, 21, 0, 0, 0, time.UTC),
+					End:   time.Date(2021, 3, 31, 23, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		output, err := maintenanceWindowOccurrences(tc.Input, tc.After, tc.Limit)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+		if len(output) != len(tc.ExpectedOutput) {
+			t.Fatalf("%s: unexpected output.\nExpected: %v\nGiven:    %v", tc.Name, tc.ExpectedOutput, output)
+		}
+		for i := range output {
+			if !output[i].Start.Equal(tc.ExpectedOutput[i].Start) || !output[i].End.Equal(tc.ExpectedOutput[i].End) {
+				t.Fatalf("%s: unexpected output.\nExpected: %v\nGiven:    %v", tc.Name, tc.ExpectedOutput, output)
+			}
+		}
+	}
+}
+
+func TestMaintenanceWindowActiveAt(t *testing.T) {
+	schedule := &dynatraceConfigV1.Schedule{
+		RecurrenceType: "DAILY",
+		Recurrence: &dynatraceConfigV1.Recurrence{
+			StartTime:       "23:30",
+			DurationMinutes: 60,
+		},
+		Start:  "2021-01-01 00:00",
+		End:    "2021-02-01 00:00",
+		ZoneId: "UTC",
+	}
+
+	cases := []struct {
+		Input          time.Time
+		ExpectedOutput bool
+	}{
+		{time.Date(2021, 1, 10, 23, 45, 0, 0, time.UTC), true},
+		{time.Date(2021, 1, 11, 0, 15, 0, 0, time.UTC), true},
+		{time.Date(2021, 1, 11, 0, 30, 0, 0, time.UTC), false},
+		{time.Date(2021, 1, 11, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2021, 3, 1, 23, 45, 0, 0, time.UTC), false},
+	}
+	for _, tc := range cases {
+		output, err := maintenanceWindowActiveAt(schedule, tc.Input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(output, tc.ExpectedOutput) {
+			t.Fatalf("Unexpected output for %s.\nExpected: %#v\nGiven:    %#v",
+				tc.Input, tc.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestSetMaintenanceWindowOccurrences(t *testing.T) {
+	schedule := &dynatraceConfigV1.Schedule{
+		RecurrenceType: "DAILY",
+		Recurrence: &dynatraceConfigV1.Recurrence{
+			StartTime:       "02:00",
+			DurationMinutes: 60,
+		},
+		Start:  "2021-01-01 00:00",
+		End:    "2022-01-01 00:00",
+		ZoneId: "UTC",
+	}
+
+	cases := []struct {
+		Name                string
+		Attributes          map[string]string
+		ExpectedCount       int
+		ExpectedOccurrences int
+	}{
+		{
+			"imported",
+			map[string]string{},
+			5,
+			5,
+		},
+		{
+			"configured",
+			map[string]string{"next_occurrences_count": "3"},
+			3,
+			3,
+		},
+		{
+			"none",
+			map[string]string{"next_occurrences_count": "0"},
+			0,
+			0,
+		},
+	}
+
+	for _, tc := range cases {
+		d := resourceDynatraceMaintenanceWindow().Data(&terraform.InstanceState{ID: "mw", Attributes: tc.Attributes})
+
+		if err := setMaintenanceWindowOccurrences(d, schedule, time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.Name, err)
+		}
+
+		if count := d.Get("next_occurrences_count").(int); count != tc.ExpectedCount {
+			t.Fatalf("%s: unexpected next_occurrences_count.\nExpected: %d\nGiven:    %d", tc.Name, tc.ExpectedCount, count)
+		}
+
+		if occurrences := d.Get("next_occurrences").([]interface{}); len(occurrences) != tc.ExpectedOccurrences {
+			t.Fatalf("%s: unexpected next_occurrences.\nExpected: %d occurrences\nGiven:    %#v", tc.Name, tc.ExpectedOccurrences, occurrences)
+		}
+
+		if nextStart := d.Get("next_start").(string); nextStart != "2021-06-02T02:00:00Z" {
+			t.Fatalf("%s: unexpected next_start %q", tc.Name, nextStart)
+		}
+	}
+}
+
+func TestValidateMaintenanceWindowSchedule(t *testing.T) {
+	monday := "MONDAY"
+
+	cases := []struct {
+		Name           string
+		Input          *dynatraceConfigV1.Schedule
+		ExpectedErrors []string
+	}{
+		{
+			"valid weekly",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "WEEKLY",
+				Recurrence: &dynatraceConfigV1.Recurrence{
+					DayOfWeek:       &monday,
+					StartTime:       "22:00",
+					DurationMinutes: 120,
+				},
+				Start:  "2021-01-01 00:00",
+				End:    "2021-12-31 00:00",
+				ZoneId: "Europe/Vienna",
+			},
+			nil,
+		},
+		{
+			"end before start",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "ONCE",
+				Start:          "2021-01-02 00:00",
+				End:            "2021-01-01 00:00",
+				ZoneId:         "UTC",
+			},
+			[]string{`schedule.0.end: "2021-01-01 00:00" must be after start "2021-01-02 00:00"`},
+		},
+		{
+			"weekly without day of week",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "WEEKLY",
+				Recurrence: &dynatraceConfigV1.Recurrence{
+					StartTime:       "22:00",
+					DurationMinutes: 20000,
+				},
+				Start:  "2021-01-01 00:00",
+				End:    "2021-12-31 00:00",
+				ZoneId: "UTC+01:00",
+			},
+			[]string{
+				`schedule.0.recurrence.0.day_of_week: is required when recurrence_type is WEEKLY, for example "MONDAY"`,
+				`schedule.0.recurrence.0.duration_minutes: must be between 1 and 10080 for a WEEKLY recurrence, got 20000`,
+			},
+		},
+		{
+			"daily without recurrence",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "DAILY",
+				Start:          "2021-01-01 00:00",
+				End:            "2021-12-31 00:00",
+				ZoneId:         "UTC",
+			},
+			[]string{`schedule.0.recurrence: a recurrence block with start_time and duration_minutes is required when recurrence_type is DAILY`},
+		},
+		{
+			"weekly that never occurs",
+			&dynatraceConfigV1.Schedule{
+				RecurrenceType: "WEEKLY",
+				Recurrence: &dynatraceConfigV1.Recurrence{
+					DayOfWeek:       &monday,
+					StartTime:       "22:00",
+					DurationMinutes: 60,
+				},
+				Start:  "2021-01-01 00:00",
+				End:    "2021-01-03 00:00",
+				ZoneId: "UTC",
+			},
+			[]string{`schedule.0.recurrence: the WEEKLY recurrence never occurs between start "2021-01-01 00:00" and end "2021-01-03 00:00"`},
+		},
+	}
+	for _, tc := range cases {
+		errs := validateMaintenanceWindowSchedule(tc.Input)
+		output := make([]string, len(errs))
+		for i, err := range errs {
+			output[i] = err.Error()
+		}
+		if len(output) == 0 && len(tc.ExpectedErrors) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(output, tc.ExpectedErrors) {
+			t.Fatalf("%s: unexpected errors.\nExpected: %#v\nGiven:    %#v", tc.Name, tc.ExpectedErrors, output)
+		}
+	}
+}
@@ -1,13 +1,49 @@
 package dynatrace
 
 import (
+	"encoding/json"
+
 	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func expandManagementZone(d *schema.ResourceData) (*dynatraceConfigV1.ManagementZone, error) {
+// managementZoneConfig is the ManagementZone of the config API with the entity
+// selector based rules, which the generated ManagementZone doesn't model.
+type managementZoneConfig struct {
+	dynatraceConfigV1.ManagementZone
+	managementZoneExtension
+}
+
+type managementZoneExtension struct {
+	EntitySelectorBasedRules []managementZoneEntitySelectorRule `json:"entitySelectorBasedRules,omitempty"`
+}
+
+type managementZoneEntitySelectorRule struct {
+	Enabled        bool   `json:"enabled"`
+	EntitySelector string `json:"entitySelector"`
+}
+
+func (mz managementZoneConfig) MarshalJSON() ([]byte, error) {
+	fields, err := jsonObjectFields(mz.ManagementZone, mz.managementZoneExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+func (mz *managementZoneConfig) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &mz.ManagementZone); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, &mz.managementZoneExtension)
+}
+
+func expandManagementZone(d *schema.ResourceData) (*managementZoneConfig, error) {
 
-	var dtManagementZone dynatraceConfigV1.ManagementZone
+	var dtManagementZone managementZoneConfig
 
 	if name, ok := d.GetOk("name"); ok {
 		dtManagementZone.SetName(name.(string))
@@ -21,10 +57,50 @@ func expandManagementZone(d *schema.ResourceData) (*dynatraceConfigV1.Management
 		dtManagementZone.SetDimensionalRules(expandDimensionalRules(dimensionalRules.([]interface{})))
 	}
 
+	if entitySelectorRules, ok := d.GetOk("entity_selector_rule"); ok {
+		dtManagementZone.EntitySelectorBasedRules = expandManagementZoneEntitySelectorRules(entitySelectorRules.([]interface{}))
+	}
+
 	return &dtManagementZone, nil
 
 }
 
+func expandManagementZoneEntitySelectorRules(rules []interface{}) []managementZoneEntitySelectorRule {
+	esrs := make([]managementZoneEntitySelectorRule, len(rules))
+
+	for i, rule := range rules {
+		m := rule.(map[string]interface{})
+
+		esrs[i] = managementZoneEntitySelectorRule{
+			Enabled:        m["enabled"].(bool),
+			EntitySelector: m["selector"].(string),
+		}
+	}
+
+	return esrs
+}
+
+func flattenDynatraceManagementZone(managementZone managementZoneConfig, d *schema.ResourceData) diag.Diagnostics {
+
+	managementZoneRules := flattenManagementZoneRulesData(managementZone.Rules)
+	selectConditionValueForms(d, managementZoneRules)
+	if err := d.Set("rule", managementZoneRules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("dimensional_rule", flattenDimensionalRules(managementZone.DimensionalRules)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("entity_selector_rule", flattenManagementZoneEntitySelectorRules(managementZone.EntitySelectorBasedRules)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", managementZone.Name)
+
+	return nil
+}
+
 func expandDimensionalRules(dimensionalRules []interface{}) []dynatraceConfigV1.DimensionalManagementZoneRuleDto {
 
 	drs := make([]dynatraceConfigV1.DimensionalManagementZoneRuleDto, len(dimensionalRules))
@@ -84,6 +160,39 @@ func expandDimensionalConditions(conditions []interface{}) []dynatraceConfigV1.D
 
 }
 
+func flattenDimensionalRules(dimensionalRules *[]dynatraceConfigV1.DimensionalManagementZoneRuleDto) []interface{} {
+	if dimensionalRules == nil {
+		return make([]interface{}, 0)
+	}
+
+	drs := make([]interface{}, len(*dimensionalRules))
+
+	for i, dimensionalRule := range *dimensionalRules {
+		drs[i] = map[string]interface{}{
+			"enabled":    dimensionalRule.Enabled,
+			"applies_to": dimensionalRule.AppliesTo,
+			"condition":  flattenDimensionalConditions(dimensionalRule.Conditions),
+		}
+	}
+
+	return drs
+}
+
+func flattenDimensionalConditions(conditions []dynatraceConfigV1.DimensionalManagementZoneConditionDto) []interface{} {
+	dcs := make([]interface{}, len(conditions))
+
+	for i, condition := range conditions {
+		dcs[i] = map[string]interface{}{
+			"condition_type": condition.ConditionType,
+			"rule_matcher":   condition.RuleMatcher,
+			"key":            condition.Key,
+			"value":          condition.GetValue(),
+		}
+	}
+
+	return dcs
+}
+
 func expandManagementZoneRules(rules []interface{}) []dynatraceConfigV1.ManagementZoneRule {
 	if len(rules) < 1 {
 		return []dynatraceConfigV1.ManagementZoneRule{}
@@ -106,7 +215,7 @@ func expandManagementZoneRules(rules []interface{}) []dynatraceConfigV1.Manageme
 		}
 
 		if propagationTypes, ok := m["propagation_types"]; ok {
-			dtManagementZoneRule.SetPropagationTypes(expandManagementZonePropagationTypes(propagationTypes.([]interface{})))
+			dtManagementZoneRule.SetPropagationTypes(expandManagementZonePropagationTypes(propagationTypes.(*schema.Set).List()))
 		}
 
 		if conditions, ok := m["condition"].([]interface{}); ok {
@@ -150,7 +259,7 @@ func flattenManagementZoneRulesData(managementZoneRules *[]dynatraceConfigV1.Man
 	return make([]interface{}, 0)
 }
 
-func flattenManagementZonePropagationTypes(propagationTypes *[]string) *[]string {
+func flattenManagementZonePropagationTypes(propagationTypes *[]string) []string {
 	if propagationTypes == nil {
 		return nil
 	}
@@ -161,5 +270,18 @@ func flattenManagementZonePropagationTypes(propagationTypes *[]string) *[]string
 		pts[i] = e
 	}
 
-	return &pts
+	return pts
+}
+
+func flattenManagementZoneEntitySelectorRules(rules []managementZoneEntitySelectorRule) []interface{} {
+	esrs := make([]interface{}, len(rules))
+
+	for i, rule := range rules {
+		esrs[i] = map[string]interface{}{
+			"enabled":  rule.Enabled,
+			"selector": rule.EntitySelector,
+		}
+	}
+
+	return esrs
 }
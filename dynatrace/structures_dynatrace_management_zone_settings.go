@@ -0,0 +1,143 @@
+package dynatrace
+
+import (
+	"fmt"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// expandManagementZoneSettings returns the value of the builtin:management-zones
+// settings object for a management zone.
+func expandManagementZoneSettings(d *schema.ResourceData) (map[string]interface{}, error) {
+	mz, err := expandManagementZone(d)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []interface{}{}
+
+	if mz.Rules != nil {
+		for i, rule := range *mz.Rules {
+			attributeRule, err := expandSettingsAttributeRule(rule.Type, rule.PropagationTypes, rule.Conditions, "attributeConditions")
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: %s", i, err)
+			}
+
+			rules = append(rules, map[string]interface{}{
+				"enabled":       rule.Enabled,
+				"type":          "ME",
+				"attributeRule": attributeRule,
+			})
+		}
+	}
+
+	if mz.DimensionalRules != nil {
+		for _, rule := range *mz.DimensionalRules {
+			conditions := []interface{}{}
+			for _, condition := range rule.Conditions {
+				c := map[string]interface{}{
+					"conditionType": condition.ConditionType,
+					"ruleMatcher":   condition.RuleMatcher,
+					"key":           condition.Key,
+				}
+				if condition.Value != nil {
+					c["value"] = *condition.Value
+				}
+				conditions = append(conditions, c)
+			}
+
+			rules = append(rules, map[string]interface{}{
+				"enabled": rule.Enabled,
+				"type":    "DIMENSION",
+				"dimensionRule": map[string]interface{}{
+					"appliesTo":           rule.AppliesTo,
+					"dimensionConditions": conditions,
+				},
+			})
+		}
+	}
+
+	for _, rule := range mz.EntitySelectorBasedRules {
+		rules = append(rules, map[string]interface{}{
+			"enabled":        rule.Enabled,
+			"type":           "SELECTOR",
+			"entitySelector": rule.EntitySelector,
+		})
+	}
+
+	return map[string]interface{}{
+		"name":  mz.Name,
+		"rules": rules,
+	}, nil
+}
+
+// flattenManagementZoneSettings turns the value of a builtin:management-zones
+// settings object into a management zone of the config v1 API, which the rules
+// are flattened from.
+func flattenManagementZoneSettings(value map[string]interface{}) (*managementZoneConfig, error) {
+	mz := &managementZoneConfig{ManagementZone: *dynatraceConfigV1.NewManagementZoneWithDefaults()}
+	mz.Name = settingsValueName(value)
+
+	rules := []dynatraceConfigV1.ManagementZoneRule{}
+	dimensionalRules := []dynatraceConfigV1.DimensionalManagementZoneRuleDto{}
+	selectorRules := []managementZoneEntitySelectorRule{}
+
+	settingsRules, _ := value["rules"].([]interface{})
+	for i, settingsRule := range settingsRules {
+		m, _ := settingsRule.(map[string]interface{})
+		enabled, _ := m["enabled"].(bool)
+
+		switch m["type"] {
+		case "ME":
+			attributeRule, _ := m["attributeRule"].(map[string]interface{})
+			entityType, propagationTypes, conditions, err := flattenSettingsAttributeRule(attributeRule, "attributeConditions")
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: %s", i, err)
+			}
+
+			rules = append(rules, dynatraceConfigV1.ManagementZoneRule{
+				Type:             entityType,
+				Enabled:          enabled,
+				PropagationTypes: propagationTypes,
+				Conditions:       conditions,
+			})
+		case "DIMENSION":
+			dimensionRule, _ := m["dimensionRule"].(map[string]interface{})
+
+			var rule dynatraceConfigV1.DimensionalManagementZoneRuleDto
+			rule.SetEnabled(enabled)
+			rule.SetAppliesTo(fmt.Sprint(dimensionRule["appliesTo"]))
+
+			conditions := []dynatraceConfigV1.DimensionalManagementZoneConditionDto{}
+			dimensionConditions, _ := dimensionRule["dimensionConditions"].([]interface{})
+			for _, dimensionCondition := range dimensionConditions {
+				c, _ := dimensionCondition.(map[string]interface{})
+
+				var condition dynatraceConfigV1.DimensionalManagementZoneConditionDto
+				condition.SetConditionType(fmt.Sprint(c["conditionType"]))
+				condition.SetRuleMatcher(fmt.Sprint(c["ruleMatcher"]))
+				condition.SetKey(fmt.Sprint(c["key"]))
+				if v, ok := c["value"].(string); ok && len(v) != 0 {
+					condition.SetValue(v)
+				}
+				conditions = append(conditions, condition)
+			}
+			rule.SetConditions(conditions)
+
+			dimensionalRules = append(dimensionalRules, rule)
+		case "SELECTOR":
+			entitySelector, _ := m["entitySelector"].(string)
+			selectorRules = append(selectorRules, managementZoneEntitySelectorRule{
+				Enabled:        enabled,
+				EntitySelector: entitySelector,
+			})
+		}
+	}
+
+	mz.SetRules(rules)
+	mz.SetDimensionalRules(dimensionalRules)
+	mz.EntitySelectorBasedRules = selectorRules
+
+	return mz, nil
+}
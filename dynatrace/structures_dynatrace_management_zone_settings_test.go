@@ -0,0 +1,119 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestManagementZoneSettingsRoundTrip(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDynatraceManagementZone().Schema, map[string]interface{}{
+		"name": "production",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"type":              "SERVICE",
+				"enabled":           true,
+				"propagation_types": []interface{}{"SERVICE_TO_HOST_LIKE"},
+				"condition": []interface{}{
+					map[string]interface{}{
+						"key": []interface{}{
+							map[string]interface{}{"attribute": "SERVICE_TAGS"},
+						},
+						"comparison_info": []interface{}{
+							map[string]interface{}{
+								"operator": "EQUALS",
+								"negate":   false,
+								"type":     "TAG",
+								"tag": []interface{}{
+									map[string]interface{}{"context": "CONTEXTLESS", "key": "stage", "value": "production"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"dimensional_rule": []interface{}{
+			map[string]interface{}{
+				"enabled":    true,
+				"applies_to": "METRIC",
+				"condition": []interface{}{
+					map[string]interface{}{"condition_type": "DIMENSION", "rule_matcher": "BEGINS_WITH", "key": "stage", "value": "prod"},
+					map[string]interface{}{"condition_type": "METRIC_KEY", "rule_matcher": "BEGINS_WITH", "key": "custom."},
+				},
+			},
+		},
+		"entity_selector_rule": []interface{}{
+			map[string]interface{}{"selector": `type(SERVICE),tag("stage:production")`},
+		},
+	})
+
+	value, err := expandManagementZoneSettings(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedRules := []interface{}{
+		map[string]interface{}{
+			"enabled": true,
+			"type":    "ME",
+			"attributeRule": map[string]interface{}{
+				"entityType":               "SERVICE",
+				"serviceToHostPropagation": true,
+				"attributeConditions": []interface{}{
+					map[string]interface{}{"key": "SERVICE_TAGS", "operator": "EQUALS", "tag": "stage:production"},
+				},
+			},
+		},
+		map[string]interface{}{
+			"enabled": true,
+			"type":    "DIMENSION",
+			"dimensionRule": map[string]interface{}{
+				"appliesTo": "METRIC",
+				"dimensionConditions": []interface{}{
+					map[string]interface{}{"conditionType": "DIMENSION", "ruleMatcher": "BEGINS_WITH", "key": "stage", "value": "prod"},
+					map[string]interface{}{"conditionType": "METRIC_KEY", "ruleMatcher": "BEGINS_WITH", "key": "custom."},
+				},
+			},
+		},
+		map[string]interface{}{
+			"enabled":        true,
+			"type":           "SELECTOR",
+			"entitySelector": `type(SERVICE),tag("stage:production")`,
+		},
+	}
+	if value["name"] != "production" || !reflect.DeepEqual(value["rules"], expectedRules) {
+		t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v", expectedRules, value["rules"])
+	}
+
+	mz, err := flattenManagementZoneSettings(value)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if mz.Name != "production" || len(*mz.Rules) != 1 {
+		t.Fatalf("Unexpected output from flattener: %#v", mz)
+	}
+
+	rule := (*mz.Rules)[0]
+	if rule.Type != "SERVICE" || !rule.Enabled || !reflect.DeepEqual(*rule.PropagationTypes, []string{"SERVICE_TO_HOST_LIKE"}) {
+		t.Fatalf("Unexpected rule from flattener: %#v", rule)
+	}
+
+	comparisonInfo := rule.Conditions[0].ComparisonInfo
+	expectedTag := map[string]interface{}{"context": "CONTEXTLESS", "key": "stage", "value": "production"}
+	if comparisonInfo.Type != "TAG" || comparisonInfo.Operator != "EQUALS" || !reflect.DeepEqual(*comparisonInfo.Value, expectedTag) {
+		t.Fatalf("Unexpected condition from flattener: %#v", comparisonInfo)
+	}
+
+	dimensionalRules := flattenDimensionalRules(mz.DimensionalRules)
+	if !reflect.DeepEqual(dimensionalRules, d.Get("dimensional_rule")) {
+		t.Fatalf("Unexpected dimensional rules.\nExpected: %#v\nGiven:    %#v", d.Get("dimensional_rule"), dimensionalRules)
+	}
+
+	selectorRules := flattenManagementZoneEntitySelectorRules(mz.EntitySelectorBasedRules)
+	if !reflect.DeepEqual(selectorRules, d.Get("entity_selector_rule")) {
+		t.Fatalf("Unexpected entity selector rules.\nExpected: %#v\nGiven:    %#v", d.Get("entity_selector_rule"), selectorRules)
+	}
+}
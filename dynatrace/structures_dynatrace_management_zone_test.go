@@ -1,10 +1,12 @@
 package dynatrace
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
 	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var keyType = "PROCESS_CUSTOM_METADATA_KEY"
@@ -67,3 +69,50 @@ func TestExpandConditionKey(t *testing.T) {
 		}
 	}
 }
+
+func TestManagementZoneEntitySelectorRulesRoundTrip(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDynatraceManagementZone().Schema, map[string]interface{}{
+		"name": "kubernetes",
+		"entity_selector_rule": []interface{}{
+			map[string]interface{}{"selector": `type(CLOUD_APPLICATION_NAMESPACE),entityName("production")`},
+			map[string]interface{}{"enabled": false, "selector": `type(PROCESS_GROUP_INSTANCE),toRelationships.isPgiOfCgi(type(CLOUD_APPLICATION),namespaceName("production"))`},
+		},
+	})
+
+	mz, err := expandManagementZone(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	body, err := json.Marshal(mz)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedRules := []interface{}{
+		map[string]interface{}{"enabled": true, "entitySelector": `type(CLOUD_APPLICATION_NAMESPACE),entityName("production")`},
+		map[string]interface{}{"enabled": false, "entitySelector": `type(PROCESS_GROUP_INSTANCE),toRelationships.isPgiOfCgi(type(CLOUD_APPLICATION),namespaceName("production"))`},
+	}
+	if fields["name"] != "kubernetes" || !reflect.DeepEqual(fields["entitySelectorBasedRules"], expectedRules) {
+		t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %s", expectedRules, body)
+	}
+
+	var managementZone managementZoneConfig
+	if err := json.Unmarshal(body, &managementZone); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if managementZone.Name != "kubernetes" {
+		t.Fatalf("Unexpected management zone: %#v", managementZone)
+	}
+
+	output := flattenManagementZoneEntitySelectorRules(managementZone.EntitySelectorBasedRules)
+	if !reflect.DeepEqual(output, d.Get("entity_selector_rule")) {
+		t.Fatalf("Unexpected output from flattener.\nExpected: %#v\nGiven:    %#v", d.Get("entity_selector_rule"), output)
+	}
+}
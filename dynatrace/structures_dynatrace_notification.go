@@ -45,15 +45,15 @@ func expandDynatraceNotification(d *schema.ResourceData) (*dynatraceConfigV1.Not
 	}
 
 	if receivers, ok := d.GetOk("receivers"); ok {
-		dtNotificationConfig.SetReceivers(expandNotificationReceivers(receivers.([]interface{})))
+		dtNotificationConfig.SetReceivers(expandNotificationReceivers(receivers.(*schema.Set).List()))
 	}
 
 	if ccReceivers, ok := d.GetOk("cc_receivers"); ok {
-		dtNotificationConfig.SetCcReceivers(expandNotificationReceivers(ccReceivers.([]interface{})))
+		dtNotificationConfig.SetCcReceivers(expandNotificationReceivers(ccReceivers.(*schema.Set).List()))
 	}
 
 	if bccReceivers, ok := d.GetOk("bcc_receivers"); ok {
-		dtNotificationConfig.SetBccReceivers(expandNotificationReceivers(bccReceivers.([]interface{})))
+		dtNotificationConfig.SetBccReceivers(expandNotificationReceivers(bccReceivers.(*schema.Set).List()))
 	}
 
 	if projectKey, ok := d.GetOk("project_key"); ok {
@@ -248,6 +248,7 @@ func flattenDynatraceNotification(notification dynatraceConfigV1.NotificationCon
 	d.Set("instance_name", &notification.InstanceName)
 	d.Set("url", &notification.Url)
 	d.Set("accept_any_certificate", &notification.AcceptAnyCertificate)
+	d.Set("payload", &notification.Payload)
 	d.Set("username", &notification.Username)
 	d.Set("password", &notification.Password)
 	d.Set("message", &notification.Message)
@@ -268,7 +269,7 @@ func flattenDynatraceNotification(notification dynatraceConfigV1.NotificationCon
 
 }
 
-func flattenNotificationReceivers(receivers *[]string) *[]string {
+func flattenNotificationReceivers(receivers *[]string) []string {
 	if receivers == nil {
 		return nil
 	}
@@ -279,7 +280,7 @@ func flattenNotificationReceivers(receivers *[]string) *[]string {
 		pts[i] = e
 	}
 
-	return &pts
+	return pts
 }
 
 func flattenNotificationHeaders(headers *[]dynatraceConfigV1.HttpHeader) []interface{} {
@@ -0,0 +1,200 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	dynatraceConfigV1 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// notificationSettingsType describes how a notification type is held by a
+// builtin:problem.notifications settings object: the property of the type's
+// settings and the properties of the config v1 notification they are taken from.
+type notificationSettingsType struct {
+	property string
+	fields   map[string]string
+}
+
+var notificationSettingsTypes = map[string]notificationSettingsType{
+	"ANSIBLETOWER": {"ansibleTowerNotification", map[string]string{
+		"jobTemplateURL":       "jobTemplateURL",
+		"acceptAnyCertificate": "acceptAnyCertificate",
+		"username":             "username",
+		"password":             "password",
+		"customMessage":        "customMessage",
+	}},
+	"EMAIL": {"emailNotification", map[string]string{
+		"subject":      "subject",
+		"body":         "body",
+		"receivers":    "recipients",
+		"ccReceivers":  "ccRecipients",
+		"bccReceivers": "bccRecipients",
+	}},
+	"JIRA": {"jiraNotification", map[string]string{
+		"url":         "url",
+		"username":    "username",
+		"password":    "apiToken",
+		"projectKey":  "projectKey",
+		"issueType":   "issueType",
+		"summary":     "summary",
+		"description": "description",
+	}},
+	"OPS_GENIE": {"opsGenieNotification", map[string]string{
+		"apiKey":  "apiKey",
+		"domain":  "domain",
+		"message": "message",
+	}},
+	"PAGER_DUTY": {"pagerDutyNotification", map[string]string{
+		"account":       "account",
+		"serviceApiKey": "serviceApiKey",
+		"serviceName":   "serviceName",
+	}},
+	"SERVICE_NOW": {"serviceNowNotification", map[string]string{
+		"instanceName":  "instanceName",
+		"url":           "url",
+		"username":      "username",
+		"password":      "password",
+		"message":       "message",
+		"sendIncidents": "sendIncidents",
+		"sendEvents":    "sendEvents",
+	}},
+	"SLACK": {"slackNotification", map[string]string{
+		"url":     "url",
+		"channel": "channel",
+		"title":   "message",
+	}},
+	"TRELLO": {"trelloNotification", map[string]string{
+		"applicationKey":     "applicationKey",
+		"authorizationToken": "authorizationToken",
+		"boardId":            "boardId",
+		"listId":             "listId",
+		"resolvedListId":     "resolvedListId",
+		"text":               "text",
+		"description":        "description",
+	}},
+	"VICTOROPS": {"victorOpsNotification", map[string]string{
+		"apiKey":     "apiKey",
+		"routingKey": "routingKey",
+		"message":    "message",
+	}},
+	"WEBHOOK": {"webHookNotification", map[string]string{
+		"url":                      "url",
+		"acceptAnyCertificate":     "acceptAnyCertificate",
+		"payload":                  "payload",
+		"headers":                  "headers",
+		"notifyEventMergesEnabled": "notifyEventMergesEnabled",
+	}},
+	"XMATTERS": {"xMattersNotification", map[string]string{
+		"url":                  "url",
+		"acceptAnyCertificate": "acceptAnyCertificate",
+		"payload":              "payload",
+		"headers":              "headers",
+	}},
+}
+
+func notificationSettingsTypeNames() []string {
+	names := make([]string, 0, len(notificationSettingsTypes))
+	for name := range notificationSettingsTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expandNotificationSettings returns the value of the builtin:problem.notifications
+// settings object for a notification.
+func expandNotificationSettings(d *schema.ResourceData) (map[string]interface{}, error) {
+	dn, err := expandDynatraceNotification(d)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := notificationSettingsTypes[dn.Type]
+	if !ok {
+		return nil, fmt.Errorf("type: %s notifications can't be stored in Settings 2.0, use one of %s", dn.Type, strings.Join(notificationSettingsTypeNames(), ", "))
+	}
+
+	data, err := json.Marshal(dn)
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	notification := map[string]interface{}{}
+	for configProperty, settingsProperty := range t.fields {
+		v, ok := config[configProperty]
+		if !ok || v == nil {
+			continue
+		}
+
+		if configProperty == "headers" {
+			headers := []interface{}{}
+			for _, header := range v.([]interface{}) {
+				h := header.(map[string]interface{})
+				headers = append(headers, map[string]interface{}{"name": h["name"], "value": h["value"], "secret": false})
+			}
+			v = headers
+		}
+
+		notification[settingsProperty] = v
+	}
+
+	return map[string]interface{}{
+		"enabled":         dn.Active,
+		"displayName":     dn.Name,
+		"type":            dn.Type,
+		"alertingProfile": dn.AlertingProfile,
+		t.property:        notification,
+	}, nil
+}
+
+// flattenNotificationSettings turns the value of a builtin:problem.notifications
+// settings object into a notification of the config v1 API, which the attributes
+// are flattened from.
+func flattenNotificationSettings(value map[string]interface{}) (*dynatraceConfigV1.NotificationConfig, error) {
+	notificationType, _ := value["type"].(string)
+
+	config := map[string]interface{}{
+		"name":            value["displayName"],
+		"alertingProfile": value["alertingProfile"],
+		"active":          value["enabled"],
+		"type":            notificationType,
+	}
+
+	t, ok := notificationSettingsTypes[notificationType]
+	if !ok {
+		return nil, fmt.Errorf("%s notifications aren't supported by the provider", notificationType)
+	}
+
+	notification, _ := value[t.property].(map[string]interface{})
+	for configProperty, settingsProperty := range t.fields {
+		if v, ok := notification[settingsProperty]; ok && v != nil {
+			config[configProperty] = v
+		}
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var dn dynatraceConfigV1.NotificationConfig
+	if err := json.Unmarshal(data, &dn); err != nil {
+		return nil, err
+	}
+
+	return &dn, nil
+}
+
+// notificationSettingsName names a notification by type and display name, since
+// display names are only unique per type.
+func notificationSettingsName(value map[string]interface{}) string {
+	return fmt.Sprintf("%v/%v", value["type"], value["displayName"])
+}
@@ -0,0 +1,107 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNotificationSettingsRoundTrip(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Input          map[string]interface{}
+		ExpectedOutput map[string]interface{}
+	}{
+		{
+			"email",
+			map[string]interface{}{
+				"name":             "Ops team",
+				"active":           true,
+				"type":             "EMAIL",
+				"alerting_profile": "c21f969b-5f03-333d-83e0-4f8f136e7682",
+				"subject":          "{State} Problem {ProblemID}",
+				"body":             "{ProblemDetailsHTML}",
+				"receivers":        []interface{}{"ops@example.com"},
+			},
+			map[string]interface{}{
+				"subject":    "{State} Problem {ProblemID}",
+				"body":       "{ProblemDetailsHTML}",
+				"recipients": []interface{}{"ops@example.com"},
+			},
+		},
+		{
+			"webhook",
+			map[string]interface{}{
+				"name":                        "Incidents",
+				"active":                      false,
+				"type":                        "WEBHOOK",
+				"alerting_profile":            "c21f969b-5f03-333d-83e0-4f8f136e7682",
+				"url":                         "https://hooks.example.com/dynatrace",
+				"payload":                     "{ProblemTitle}",
+				"notify_event_merges_enabled": true,
+				"headers": []interface{}{
+					map[string]interface{}{"name": "Content-Type", "value": "application/json"},
+				},
+			},
+			map[string]interface{}{
+				"url":                      "https://hooks.example.com/dynatrace",
+				"acceptAnyCertificate":     false,
+				"payload":                  "{ProblemTitle}",
+				"notifyEventMergesEnabled": true,
+				"headers": []interface{}{
+					map[string]interface{}{"name": "Content-Type", "value": "application/json", "secret": false},
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceDynatraceNotification().Schema, c.Input)
+
+		value, err := expandNotificationSettings(d)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		property := notificationSettingsTypes[c.Input["type"].(string)].property
+		if !reflect.DeepEqual(value[property], c.ExpectedOutput) {
+			t.Fatalf("%s: unexpected output from expander.\nExpected: %#v\nGiven:    %#v", c.Name, c.ExpectedOutput, value[property])
+		}
+
+		if value["displayName"] != c.Input["name"] || value["enabled"] != c.Input["active"] {
+			t.Fatalf("%s: unexpected value from expander: %#v", c.Name, value)
+		}
+
+		notification, err := flattenNotificationSettings(value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		flattened := schema.TestResourceDataRaw(t, resourceDynatraceNotification().Schema, map[string]interface{}{})
+		flattenDynatraceNotification(*notification, flattened)
+
+		for key := range c.Input {
+			expected, given := d.Get(key), flattened.Get(key)
+			if set, ok := expected.(*schema.Set); ok {
+				expected, given = set.List(), given.(*schema.Set).List()
+			}
+
+			if !reflect.DeepEqual(given, expected) {
+				t.Fatalf("%s: unexpected %s from flattener.\nExpected: %#v\nGiven:    %#v", c.Name, key, expected, given)
+			}
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDynatraceNotification().Schema, map[string]interface{}{"name": "Chat", "active": true, "type": "HIPCHAT"})
+	if _, err := expandNotificationSettings(d); err == nil {
+		t.Fatalf("Expected an error for a notification type without Settings 2.0 equivalent")
+	}
+}
+
+func TestNotificationSettingsName(t *testing.T) {
+	name := notificationSettingsName(map[string]interface{}{"type": "EMAIL", "displayName": "Ops team"})
+	if name != "EMAIL/Ops team" {
+		t.Fatalf("Unexpected name %q", name)
+	}
+}
@@ -0,0 +1,92 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"strings"
+
+	dynatraceEnvironmentV2 "github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/environment/dynatrace"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func expandSettingsObjectCreate(d *schema.ResourceData) (*dynatraceEnvironmentV2.SettingsObjectCreate, error) {
+	value, err := decodeSettingsValue(d.Get("value").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	dtSettingsObject := dynatraceEnvironmentV2.NewSettingsObjectCreate(d.Get("scope").(string), d.Get("schema_id").(string), value)
+
+	if schemaVersion, ok := d.GetOk("schema_version"); ok {
+		dtSettingsObject.SetSchemaVersion(schemaVersion.(string))
+	}
+
+	if insertAfter, ok := d.GetOk("insert_after"); ok {
+		dtSettingsObject.SetInsertAfter(insertAfter.(string))
+	}
+
+	return dtSettingsObject, nil
+}
+
+func expandSettingsObjectUpdate(d *schema.ResourceData) (*dynatraceEnvironmentV2.SettingsObjectUpdate, error) {
+	value, err := decodeSettingsValue(d.Get("value").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	dtSettingsObject := dynatraceEnvironmentV2.NewSettingsObjectUpdate(value)
+
+	if schemaVersion, ok := d.GetOk("schema_version"); ok {
+		dtSettingsObject.SetSchemaVersion(schemaVersion.(string))
+	}
+
+	return dtSettingsObject, nil
+}
+
+func flattenSettingsObject(settingsObject *foundSettingsObject, d *schema.ResourceData) diag.Diagnostics {
+	d.Set("schema_id", settingsObject.SchemaID)
+	d.Set("schema_version", settingsObject.SchemaVersion)
+	d.Set("scope", settingsObject.Scope)
+	d.Set("summary", settingsObject.Summary)
+
+	var value interface{} = map[string]interface{}{}
+	if len(settingsObject.Value) != 0 && string(settingsObject.Value) != "null" {
+		decoded, err := decodeSettingsValue(string(settingsObject.Value))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		value = decoded
+	}
+
+	// properties left out of the configuration get their defaults from the schema,
+	// they are only kept when nothing was configured yet, e.g. on import
+	if configured, ok := d.Get("value").(string); ok && len(configured) != 0 {
+		if configuredValue, err := decodeSettingsValue(configured); err == nil {
+			value = projectJSON(value, configuredValue)
+		}
+	}
+
+	contents, err := encodeJSON(value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("value", contents); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// decodeSettingsValue parses the value of a settings object, keeping numbers as written.
+func decodeSettingsValue(contents string) (map[string]interface{}, error) {
+	var value map[string]interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(contents))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
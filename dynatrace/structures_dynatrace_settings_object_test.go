@@ -0,0 +1,62 @@
+package dynatrace
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeSettingsValue(t *testing.T) {
+	value, err := decodeSettingsValue(`{"threshold":1.50}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	output, _ := encodeJSON(value)
+	if !reflect.DeepEqual(output, `{"threshold":1.50}`) {
+		t.Fatalf("Expected numbers to be kept as written, got %s", output)
+	}
+
+	if _, err := decodeSettingsValue(`[]`); err == nil {
+		t.Fatalf("Expected an error for a value that isn't an object")
+	}
+}
+
+func TestFlattenSettingsObject(t *testing.T) {
+	settingsObject := &foundSettingsObject{
+		SchemaID:      "builtin:anomaly-detection.metric-events",
+		SchemaVersion: "1.0.2",
+		Scope:         "environment",
+		Value:         []byte(`{"threshold":1.0,"samples":9007199254740993}`),
+	}
+
+	d := resourceDynatraceSettingsObject().Data(nil)
+	if diags := flattenSettingsObject(settingsObject, d); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	if value := d.Get("value").(string); value != `{"samples":9007199254740993,"threshold":1.0}` {
+		t.Fatalf("Expected numbers to be kept as returned by the API, got %s", value)
+	}
+	if schemaID := d.Get("schema_id").(string); schemaID != settingsObject.SchemaID {
+		t.Fatalf("Unexpected schema_id %s", schemaID)
+	}
+}
+
+func TestFlattenSettingsObjectDefaults(t *testing.T) {
+	settingsObject := &foundSettingsObject{
+		SchemaID:      "builtin:alerting.profile",
+		SchemaVersion: "2.0.1",
+		Scope:         "environment",
+		Value:         []byte(`{"name":"Ops","managementZone":null,"severityRules":[{"severityLevel":"ERROR","delayInMinutes":0,"tagFilterIncludeMode":"NONE"}]}`),
+	}
+
+	d := resourceDynatraceSettingsObject().Data(nil)
+	d.Set("value", `{"name":"Ops","severityRules":[{"severityLevel":"ERROR"}]}`)
+	if diags := flattenSettingsObject(settingsObject, d); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	if value := d.Get("value").(string); value != `{"name":"Ops","severityRules":[{"severityLevel":"ERROR"}]}` {
+		t.Fatalf("Expected the defaults of the API to be dropped, got %s", value)
+	}
+}
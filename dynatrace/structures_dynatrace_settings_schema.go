@@ -0,0 +1,453 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// settingsSchema is a schema of the Settings 2.0 API. The generated client can't
+// decode schemas, it expects objects where the API returns the names of types and
+// the values of enums.
+type settingsSchema struct {
+	SchemaID      string                            `json:"schemaId"`
+	Version       string                            `json:"version"`
+	DisplayName   string                            `json:"displayName"`
+	Description   string                            `json:"description"`
+	MultiObject   bool                              `json:"multiObject"`
+	Ordered       bool                              `json:"ordered"`
+	MaxObjects    int                               `json:"maxObjects"`
+	AllowedScopes []string                          `json:"allowedScopes"`
+	Enums         map[string]settingsSchemaEnum     `json:"enums"`
+	Types         map[string]settingsSchemaType     `json:"types"`
+	Properties    map[string]settingsSchemaProperty `json:"properties"`
+}
+
+type settingsSchemaEnum struct {
+	DisplayName string                   `json:"displayName"`
+	Items       []settingsSchemaEnumItem `json:"items"`
+}
+
+type settingsSchemaEnumItem struct {
+	Value       interface{} `json:"value"`
+	DisplayName string      `json:"displayName"`
+}
+
+type settingsSchemaType struct {
+	DisplayName string                            `json:"displayName"`
+	Properties  map[string]settingsSchemaProperty `json:"properties"`
+}
+
+type settingsSchemaProperty struct {
+	DisplayName  string                     `json:"displayName"`
+	Description  string                     `json:"description"`
+	Type         json.RawMessage            `json:"type"`
+	Items        *settingsSchemaProperty    `json:"items"`
+	Nullable     bool                       `json:"nullable"`
+	Default      interface{}                `json:"default"`
+	Precondition json.RawMessage            `json:"precondition"`
+	Constraints  []settingsSchemaConstraint `json:"constraints"`
+}
+
+type settingsSchemaConstraint struct {
+	Type          string   `json:"type"`
+	MinLength     *int     `json:"minLength"`
+	MaxLength     *int     `json:"maxLength"`
+	Minimum       *float64 `json:"minimum"`
+	Maximum       *float64 `json:"maximum"`
+	Pattern       string   `json:"pattern"`
+	CustomMessage string   `json:"customMessage"`
+}
+
+// typeName returns the type of a property, like text or list. Enums and nested
+// objects are references, for which the kind (enum or setting) and the name of
+// the referenced enum or type are returned.
+func (p settingsSchemaProperty) typeName() (string, string) {
+	var name string
+	if err := json.Unmarshal(p.Type, &name); err == nil {
+		return name, ""
+	}
+
+	var ref struct {
+		Ref string `json:"$ref"`
+	}
+	if err := json.Unmarshal(p.Type, &ref); err == nil {
+		switch {
+		case strings.HasPrefix(ref.Ref, "#/enums/"):
+			return "enum", strings.TrimPrefix(ref.Ref, "#/enums/")
+		case strings.HasPrefix(ref.Ref, "#/types/"):
+			return "setting", strings.TrimPrefix(ref.Ref, "#/types/")
+		}
+	}
+
+	return "", ""
+}
+
+func (e settingsSchemaEnum) values() []string {
+	values := make([]string, 0, len(e.Items))
+	for _, item := range e.Items {
+		values = append(values, fmt.Sprint(item.Value))
+	}
+	return values
+}
+
+// fetchSettingsSchema returns a schema of the Settings 2.0 API, the latest version
+// if none is given. Schemas are cached for the lifetime of the provider.
+func fetchSettingsSchema(providerConf *ProviderConfiguration, schemaID string, version string) (*settingsSchema, error) {
+	key := schemaID + "@" + version
+
+	providerConf.settingsSchemasLock.Lock()
+	defer providerConf.settingsSchemasLock.Unlock()
+
+	if s, ok := providerConf.settingsSchemas[key]; ok {
+		return s, nil
+	}
+
+	path := "/settings/schemas/" + url.PathEscape(schemaID)
+	if len(version) != 0 {
+		path += "?schemaVersion=" + url.QueryEscape(version)
+	}
+
+	response, err := restRequest(providerConf.AuthEnvironmentV2, providerConf.environmentV2URL, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var s settingsSchema
+	if err := json.Unmarshal(response, &s); err != nil {
+		return nil, err
+	}
+
+	if providerConf.settingsSchemas == nil {
+		providerConf.settingsSchemas = map[string]*settingsSchema{}
+	}
+	providerConf.settingsSchemas[key] = &s
+
+	return &s, nil
+}
+
+// validateSettingsScope checks that objects of a schema can target a scope, which
+// is either environment or the ID of an entity like HOST-0123456789ABCDEF.
+func validateSettingsScope(s *settingsSchema, scope string) error {
+	if len(s.AllowedScopes) == 0 {
+		return nil
+	}
+
+	scopeType := scope
+	if i := strings.LastIndex(scope, "-"); i > 0 && scope != "environment" {
+		scopeType = scope[:i]
+	}
+
+	for _, allowed := range s.AllowedScopes {
+		if allowed == scopeType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("scope: %s objects can't target %s, use one of %s", s.SchemaID, scope, strings.Join(s.AllowedScopes, ", "))
+}
+
+// validateSettingsValue checks the value of a settings object against its schema:
+// unknown properties, missing properties, types, enums and constraints. Constraints
+// the provider doesn't know, like custom validators, are left to the API.
+func validateSettingsValue(s *settingsSchema, value map[string]interface{}) []error {
+	return validateSettingsProperties(s, s.Properties, value, "value.")
+}
+
+func validateSettingsProperties(s *settingsSchema, properties map[string]settingsSchemaProperty, value map[string]interface{}, prefix string) []error {
+	var errs []error
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := make([]string, 0, len(value))
+	for k := range value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, ok := properties[k]; !ok {
+			errs = append(errs, fmt.Errorf("%s%s: is not a property of %s, use one of %s", prefix, k, s.SchemaID, strings.Join(names, ", ")))
+		}
+	}
+
+	for _, name := range names {
+		property := properties[name]
+
+		v, ok := value[name]
+		if !ok || v == nil {
+			// properties with preconditions are only required if these are met
+			if !ok && !property.Nullable && property.Default == nil && len(property.Precondition) == 0 {
+				errs = append(errs, fmt.Errorf("%s%s: is required", prefix, name))
+			}
+			continue
+		}
+
+		errs = append(errs, validateSettingsProperty(s, property, v, prefix+name)...)
+	}
+
+	return errs
+}
+
+func validateSettingsProperty(s *settingsSchema, property settingsSchemaProperty, v interface{}, path string) []error {
+	var errs []error
+
+	typeName, ref := property.typeName()
+
+	switch typeName {
+	case "text", "secret", "local_time", "local_date", "time_zone", "zoned_date_time":
+		str, ok := v.(string)
+		if !ok {
+			return []error{fmt.Errorf("%s: must be a string", path)}
+		}
+		errs = append(errs, validateSettingsConstraints(property.Constraints, str, path)...)
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return []error{fmt.Errorf("%s: must be true or false", path)}
+		}
+	case "integer", "float":
+		number, ok := v.(json.Number)
+		if !ok {
+			return []error{fmt.Errorf("%s: must be a number", path)}
+		}
+		if _, err := number.Int64(); err != nil && typeName == "integer" {
+			return []error{fmt.Errorf("%s: must be an integer", path)}
+		}
+		errs = append(errs, validateSettingsConstraints(property.Constraints, number, path)...)
+	case "enum":
+		enum, ok := s.Enums[ref]
+		if !ok {
+			break
+		}
+		values := enum.values()
+		if !conditionContains(values, fmt.Sprint(v)) {
+			return []error{fmt.Errorf("%s: %v is not a value of %s, use one of %s", path, v, ref, strings.Join(values, ", "))}
+		}
+	case "setting":
+		t, ok := s.Types[ref]
+		if !ok {
+			break
+		}
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return []error{fmt.Errorf("%s: must be an object", path)}
+		}
+		errs = append(errs, validateSettingsProperties(s, t.Properties, object, path+".")...)
+	case "list", "set":
+		elements, ok := v.([]interface{})
+		if !ok {
+			return []error{fmt.Errorf("%s: must be an array", path)}
+		}
+		errs = append(errs, validateSettingsConstraints(property.Constraints, elements, path)...)
+		if property.Items != nil {
+			for i, element := range elements {
+				errs = append(errs, validateSettingsProperty(s, *property.Items, element, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateSettingsConstraints(constraints []settingsSchemaConstraint, v interface{}, path string) []error {
+	var errs []error
+
+	for _, constraint := range constraints {
+		var err error
+
+		switch value := v.(type) {
+		case string:
+			err = validateSettingsStringConstraint(constraint, value)
+		case json.Number:
+			err = validateSettingsNumberConstraint(constraint, value)
+		case []interface{}:
+			if constraint.Type == "LENGTH" {
+				err = validateSettingsLength(constraint, len(value), "elements")
+			}
+		}
+
+		if err != nil {
+			if len(constraint.CustomMessage) != 0 {
+				err = fmt.Errorf("%s", constraint.CustomMessage)
+			}
+			errs = append(errs, fmt.Errorf("%s: %s", path, err))
+		}
+	}
+
+	return errs
+}
+
+func validateSettingsStringConstraint(constraint settingsSchemaConstraint, value string) error {
+	switch constraint.Type {
+	case "NOT_BLANK":
+		if len(strings.TrimSpace(value)) == 0 {
+			return fmt.Errorf("must not be blank")
+		}
+	case "LENGTH":
+		return validateSettingsLength(constraint, utf8.RuneCountInString(value), "characters")
+	case "PATTERN":
+		// patterns are Java regular expressions, those Go can't compile are left to the API
+		if re, err := regexp.Compile(constraint.Pattern); err == nil && !re.MatchString(value) {
+			return fmt.Errorf("must match %s", constraint.Pattern)
+		}
+	}
+	return nil
+}
+
+func validateSettingsNumberConstraint(constraint settingsSchemaConstraint, value json.Number) error {
+	if constraint.Type != "RANGE" {
+		return nil
+	}
+
+	number, err := value.Float64()
+	if err != nil {
+		return nil
+	}
+
+	if constraint.Minimum != nil && number < *constraint.Minimum {
+		return fmt.Errorf("must be at least %v", *constraint.Minimum)
+	}
+	if constraint.Maximum != nil && number > *constraint.Maximum {
+		return fmt.Errorf("must be at most %v", *constraint.Maximum)
+	}
+	return nil
+}
+
+func validateSettingsLength(constraint settingsSchemaConstraint, length int, unit string) error {
+	if constraint.MinLength != nil && length < *constraint.MinLength {
+		return fmt.Errorf("must have at least %d %s", *constraint.MinLength, unit)
+	}
+	if constraint.MaxLength != nil && length > *constraint.MaxLength {
+		return fmt.Errorf("must have at most %d %s", *constraint.MaxLength, unit)
+	}
+	return nil
+}
+
+func flattenSettingsSchema(s *settingsSchema, d *schema.ResourceData) diag.Diagnostics {
+	d.Set("version", s.Version)
+	d.Set("display_name", s.DisplayName)
+	d.Set("description", s.Description)
+	d.Set("multi_object", s.MultiObject)
+	d.Set("ordered", s.Ordered)
+	d.Set("max_objects", s.MaxObjects)
+	d.Set("allowed_scopes", s.AllowedScopes)
+
+	if err := d.Set("property", flattenSettingsSchemaProperties(s.Properties)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := make([]string, 0, len(s.Types))
+	for name := range s.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	types := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		types = append(types, map[string]interface{}{
+			"name":         name,
+			"display_name": s.Types[name].DisplayName,
+			"property":     flattenSettingsSchemaProperties(s.Types[name].Properties),
+		})
+	}
+	if err := d.Set("type", types); err != nil {
+		return diag.FromErr(err)
+	}
+
+	names = names[:0]
+	for name := range s.Enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	enums := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		enums = append(enums, map[string]interface{}{
+			"name":         name,
+			"display_name": s.Enums[name].DisplayName,
+			"values":       s.Enums[name].values(),
+		})
+	}
+	if err := d.Set("enum", enums); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenSettingsSchemaProperties(properties map[string]settingsSchemaProperty) []interface{} {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ps := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		property := properties[name]
+
+		typeName, ref := property.typeName()
+		p := map[string]interface{}{
+			"name":         name,
+			"display_name": property.DisplayName,
+			"description":  property.Description,
+			"type":         typeName,
+			"reference":    ref,
+			"nullable":     property.Nullable,
+			"default":      "",
+			"constraint":   flattenSettingsSchemaConstraints(property.Constraints),
+		}
+
+		if property.Items != nil {
+			p["items_type"], p["items_reference"] = property.Items.typeName()
+			p["constraint"] = append(p["constraint"].([]interface{}), flattenSettingsSchemaConstraints(property.Items.Constraints)...)
+		}
+
+		if property.Default != nil {
+			if contents, err := encodeJSON(property.Default); err == nil {
+				p["default"] = contents
+			}
+		}
+
+		ps = append(ps, p)
+	}
+
+	return ps
+}
+
+func flattenSettingsSchemaConstraints(constraints []settingsSchemaConstraint) []interface{} {
+	cs := make([]interface{}, 0, len(constraints))
+	for _, constraint := range constraints {
+		c := map[string]interface{}{
+			"type":           constraint.Type,
+			"pattern":        constraint.Pattern,
+			"custom_message": constraint.CustomMessage,
+		}
+		if constraint.MinLength != nil {
+			c["min_length"] = *constraint.MinLength
+		}
+		if constraint.MaxLength != nil {
+			c["max_length"] = *constraint.MaxLength
+		}
+		if constraint.Minimum != nil {
+			c["minimum"] = *constraint.Minimum
+		}
+		if constraint.Maximum != nil {
+			c["maximum"] = *constraint.Maximum
+		}
+		cs = append(cs, c)
+	}
+	return cs
+}
@@ -0,0 +1,118 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testSettingsSchema = `{
+	"schemaId": "builtin:alerting.profile",
+	"version": "8.1",
+	"allowedScopes": ["environment"],
+	"enums": {
+		"SeverityLevel": {"items": [{"value": "AVAILABILITY"}, {"value": "ERROR"}]}
+	},
+	"types": {
+		"SeverityRule": {
+			"properties": {
+				"severityLevel": {"type": {"$ref": "#/enums/SeverityLevel"}},
+				"delayInMinutes": {"type": "integer", "default": 0, "constraints": [{"type": "RANGE", "minimum": 0, "maximum": 10000}]}
+			}
+		}
+	},
+	"properties": {
+		"name": {"type": "text", "constraints": [{"type": "NOT_BLANK"}, {"type": "LENGTH", "maxLength": 10}]},
+		"enabled": {"type": "boolean", "default": true},
+		"managementZone": {"type": "text", "nullable": true, "constraints": [{"type": "PATTERN", "pattern": "^[0-9]+$", "customMessage": "Must be the ID of a management zone"}]},
+		"severityRules": {"type": "list", "items": {"type": {"$ref": "#/types/SeverityRule"}}, "constraints": [{"type": "LENGTH", "maxLength": 2}]}
+	}
+}`
+
+func TestValidateSettingsValue(t *testing.T) {
+	var s settingsSchema
+	if err := json.Unmarshal([]byte(testSettingsSchema), &s); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	cases := []struct {
+		Name           string
+		Input          string
+		ExpectedOutput []string
+	}{
+		{
+			"valid",
+			`{"name":"Ops","managementZone":"123","severityRules":[{"severityLevel":"ERROR","delayInMinutes":5}]}`,
+			nil,
+		},
+		{
+			"unknown and missing properties",
+			`{"nmae":"Ops"}`,
+			[]string{
+				"value.nmae: is not a property of builtin:alerting.profile, use one of enabled, managementZone, name, severityRules",
+				"value.name: is required",
+				"value.severityRules: is required",
+			},
+		},
+		{
+			"types",
+			`{"name":1,"enabled":"yes","severityRules":{}}`,
+			[]string{
+				"value.enabled: must be true or false",
+				"value.name: must be a string",
+				"value.severityRules: must be an array",
+			},
+		},
+		{
+			"constraints",
+			`{"name":" ","managementZone":"zone","severityRules":[]}`,
+			[]string{
+				"value.managementZone: Must be the ID of a management zone",
+				"value.name: must not be blank",
+			},
+		},
+		{
+			"nested objects",
+			`{"name":"Operations!","severityRules":[{"severityLevel":"WARNING"},{"severityLevel":"ERROR","delayInMinutes":1.5},{"severityLevel":"ERROR","delayInMinutes":-1}]}`,
+			[]string{
+				"value.name: must have at most 10 characters",
+				"value.severityRules: must have at most 2 elements",
+				"value.severityRules[0].severityLevel: WARNING is not a value of SeverityLevel, use one of AVAILABILITY, ERROR",
+				"value.severityRules[1].delayInMinutes: must be an integer",
+				"value.severityRules[2].delayInMinutes: must be at least 0",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		value, err := decodeSettingsValue(c.Input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.Name, err)
+		}
+
+		var output []string
+		for _, err := range validateSettingsValue(&s, value) {
+			output = append(output, err.Error())
+		}
+
+		if !reflect.DeepEqual(output, c.ExpectedOutput) {
+			t.Fatalf("%s: unexpected output.\nExpected: %q\nGiven:    %q", c.Name, c.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestValidateSettingsScope(t *testing.T) {
+	s := &settingsSchema{SchemaID: "builtin:anomaly-detection.infrastructure-hosts", AllowedScopes: []string{"HOST", "HOST_GROUP", "environment"}}
+
+	for _, scope := range []string{"environment", "HOST-0123456789ABCDEF", "HOST_GROUP-0123456789ABCDEF"} {
+		if err := validateSettingsScope(s, scope); err != nil {
+			t.Fatalf("%s: unexpected error: %s", scope, err)
+		}
+	}
+
+	err := validateSettingsScope(s, "PROCESS_GROUP-0123456789ABCDEF")
+	expected := "scope: builtin:anomaly-detection.infrastructure-hosts objects can't target PROCESS_GROUP-0123456789ABCDEF, use one of HOST, HOST_GROUP, environment"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Unexpected error.\nExpected: %s\nGiven:    %v", expected, err)
+	}
+}
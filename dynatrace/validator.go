@@ -0,0 +1,235 @@
+package dynatrace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateOnPlan sends the planned configuration of a resource to the validator
+// endpoint of its config API collection, for example /managementZones, and turns
+// the constraint violations of the response into a plan error. It only does so
+// if the provider is configured with validate_on_plan and the resource is new or
+// changed, and skips plans holding values that are not known yet.
+func validateOnPlan(d *schema.ResourceDiff, m interface{}, r *schema.Resource, description string, path string, expand func(*schema.ResourceData) (interface{}, error)) error {
+	providerConf, ok := m.(*ProviderConfiguration)
+	if !ok || !providerConf.ValidateOnPlan {
+		return nil
+	}
+
+	if len(d.Id()) != 0 && !resourceDiffChanged(d, r.Schema) {
+		return nil
+	}
+
+	if !resourceDiffKnown(d, r.Schema, "") {
+		return nil
+	}
+
+	data, err := resourceDataFromDiff(d, r)
+	if err != nil {
+		return err
+	}
+
+	config, err := expand(data)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	validatorPath := path + "/validator"
+	if len(d.Id()) != 0 {
+		validatorPath = path + "/" + d.Id() + "/validator"
+	}
+
+	_, err = restRequest(providerConf.AuthConfigV1, providerConf.configV1URL, http.MethodPost, validatorPath, body)
+	if err == nil {
+		return nil
+	}
+
+	restErr, ok := err.(RESTError)
+	if !ok || restErr.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("unable to validate %s: %s", description, getErrorMessage(err))
+	}
+
+	apiErr, ok := restErr.APIError()
+	if !ok {
+		return fmt.Errorf("unable to validate %s: %s", description, getErrorMessage(err))
+	}
+
+	var messages []string
+	for _, violation := range apiErr.ConstraintViolations {
+		attribute := ""
+		if violation.ParameterLocation == "" || violation.ParameterLocation == "PAYLOAD_BODY" {
+			attribute = attributePathFromAPI(r.Schema, violation.Path)
+		}
+
+		if len(attribute) != 0 {
+			messages = append(messages, fmt.Sprintf("  - %s: %s", attribute, violation.Message))
+		} else {
+			messages = append(messages, "  - "+violation.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		messages = append(messages, "  - "+apiErr.Message)
+	}
+
+	return fmt.Errorf("invalid %s according to the Dynatrace API:\n%s", description, strings.Join(messages, "\n"))
+}
+
+// resourceDiffChanged reports whether any attribute of the resource changes.
+func resourceDiffChanged(d *schema.ResourceDiff, s map[string]*schema.Schema) bool {
+	for k := range s {
+		if d.HasChange(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceDiffKnown reports whether all configurable values of the plan are known,
+// descending into nested blocks. Computed attributes without a configured value
+// are ignored, they are not sent to the API.
+func resourceDiffKnown(d *schema.ResourceDiff, s map[string]*schema.Schema, prefix string) bool {
+	for k, v := range s {
+		if !v.Optional && !v.Required {
+			continue
+		}
+
+		key := prefix + k
+
+		if !d.NewValueKnown(key) {
+			if v.Computed {
+				continue
+			}
+			return false
+		}
+
+		elem, ok := v.Elem.(*schema.Resource)
+		if !ok || v.Type != schema.TypeList {
+			continue
+		}
+
+		count, _ := d.Get(key + ".#").(int)
+		for i := 0; i < count; i++ {
+			if !resourceDiffKnown(d, elem.Schema, fmt.Sprintf("%s.%d.", key, i)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// resourceDataFromDiff copies the planned values into a ResourceData, so that the
+// expand functions of the resource can build the request body from them.
+func resourceDataFromDiff(d *schema.ResourceDiff, r *schema.Resource) (*schema.ResourceData, error) {
+	data := r.Data(nil)
+	data.SetId(d.Id())
+
+	for k, v := range r.Schema {
+		if !v.Optional && !v.Required {
+			continue
+		}
+
+		if !d.NewValueKnown(k) {
+			continue
+		}
+
+		if err := data.Set(k, d.Get(k)); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+var apiPathSegment = regexp.MustCompile(`^([A-Za-z0-9_]+)(?:\[(\d+)\])?$`)
+
+// attributePathFromAPI maps the JSON path of a constraint violation, for example
+// rules[0].conditions[1].comparisonInfo.value, onto the attribute of the resource
+// it stems from, rule.0.condition.1.comparison_info.0.value. Field names are
+// matched in snake case, in singular for lists. The path is cut off at the first
+// field that has no attribute.
+func attributePathFromAPI(s map[string]*schema.Schema, path string) string {
+	var parts []string
+
+segments:
+	for _, segment := range strings.Split(path, ".") {
+		match := apiPathSegment.FindStringSubmatch(segment)
+		if match == nil || s == nil {
+			break
+		}
+
+		name, ok := schemaAttributeName(s, match[1])
+		if !ok {
+			break
+		}
+
+		attribute := s[name]
+		parts = append(parts, name)
+		s = nil
+
+		if attribute.Type != schema.TypeList {
+			break
+		}
+
+		elem, ok := attribute.Elem.(*schema.Resource)
+
+		switch {
+		case len(match[2]) != 0:
+			parts = append(parts, match[2])
+		case ok:
+			// a single nested object is a block with one element
+			parts = append(parts, "0")
+		default:
+			break segments
+		}
+
+		if ok {
+			s = elem.Schema
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// schemaAttributeName returns the attribute a JSON field name corresponds to.
+func schemaAttributeName(s map[string]*schema.Schema, field string) (string, bool) {
+	name := camelToSnakeCase(field)
+
+	for _, candidate := range []string{name, strings.TrimSuffix(name, "s"), strings.TrimSuffix(name, "es")} {
+		if _, ok := s[candidate]; ok {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+func camelToSnakeCase(s string) string {
+	var b strings.Builder
+
+	for i, c := range s {
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 {
+				prev := s[i-1]
+				if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') {
+					b.WriteByte('_')
+				}
+			}
+			c += 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
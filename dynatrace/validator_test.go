@@ -0,0 +1,82 @@
+package dynatrace
+
+import (
+	"testing"
+)
+
+func TestAttributePathFromAPI(t *testing.T) {
+	managementZone := resourceDynatraceManagementZone().Schema
+	dashboard := resourceDynatraceDashboard().Schema
+
+	cases := []struct {
+		Name           string
+		Input          string
+		ExpectedOutput string
+	}{
+		{
+			"nested block",
+			"rules[0].conditions[1].comparisonInfo.value",
+			"rule.0.condition.1.comparison_info.0.value",
+		},
+		{
+			"camel case",
+			"dimensionalRules[2].appliesTo",
+			"dimensional_rule.2.applies_to",
+		},
+		{
+			"set of strings",
+			"rules[0].propagationTypes[1]",
+			"rule.0.propagation_types",
+		},
+		{
+			"unknown field",
+			"rules[0].unknownField",
+			"rule.0",
+		},
+		{
+			"root",
+			"name",
+			"name",
+		},
+		{
+			"no attribute",
+			"id",
+			"",
+		},
+	}
+	for _, tc := range cases {
+		output := attributePathFromAPI(managementZone, tc.Input)
+		if output != tc.ExpectedOutput {
+			t.Fatalf("%s: unexpected output.\nExpected: %s\nGiven:    %s", tc.Name, tc.ExpectedOutput, output)
+		}
+	}
+
+	output := attributePathFromAPI(dashboard, "tiles[3].bounds.width")
+	if output != "tile.3.bounds.0.width" {
+		t.Fatalf("Unexpected output for a dashboard tile: %s", output)
+	}
+
+	output = attributePathFromAPI(dashboard, "dashboardMetadata.validFilterKeys[1]")
+	if output != "dashboard_metadata.0.valid_filter_keys.1" {
+		t.Fatalf("Unexpected output for a list of strings: %s", output)
+	}
+}
+
+func TestRESTErrorAPIError(t *testing.T) {
+	restErr := RESTError{
+		StatusCode: 400,
+		body:       []byte(`{"error":{"code":400,"message":"Constraints violated.","constraintViolations":[{"path":"name","message":"must not be empty","parameterLocation":"PAYLOAD_BODY"}]}}`),
+	}
+
+	apiErr, ok := restErr.APIError()
+	if !ok {
+		t.Fatalf("Expected the body to decode")
+	}
+	if apiErr.Message != "Constraints violated." || len(apiErr.ConstraintViolations) != 1 || apiErr.ConstraintViolations[0].Path != "name" {
+		t.Fatalf("Unexpected error: %#v", apiErr)
+	}
+
+	if _, ok := (RESTError{body: []byte("<html></html>")}).APIError(); ok {
+		t.Fatalf("Expected a body that is not JSON not to decode")
+	}
+}
@@ -7,6 +7,7 @@ require (
 	github.com/dynatrace-ace/dynatrace-go-api-client/api/v1/config/dynatrace v0.0.0-20210224231622-f7713c7b0481
 	github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/cluster/dynatrace v0.0.0-20210707202240-79bd90863016
 	github.com/dynatrace-ace/dynatrace-go-api-client/api/v2/environment/dynatrace v0.0.0-20210710142934-a5a363877d68
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.4.4
 	github.com/imdario/mergo v0.3.11 // indirect
 	github.com/mattn/go-colorable v0.1.8 // indirect